@@ -36,7 +36,8 @@ wide range of API protocols including REST, GraphQL, gRPC, WebSockets,
 SOAP, XMLRPC, and others.
 Homepage: https://github.com/wallarm/gotestwaf
 
-Usage: %s [OPTIONS] --url <URL>
+Usage: %[1]s [OPTIONS] --url <URL>
+       %[1]s testcases <validate|list> [OPTIONS]
 
 Options:
 `
@@ -71,6 +72,21 @@ func parseFlags() (args string, err error) {
 	urlParam := flag.String("url", "", "URL to check")
 	wsURL := flag.String("wsURL", "", "WebSocket URL to check")
 	flag.Uint16("grpcPort", 0, "gRPC port to check")
+	flag.StringSlice("grpcProto", nil, "Path to a .proto file with gRPC services to check (can be repeated)")
+	flag.StringSlice("grpcImportPath", nil, "Directory to search for .proto imports (can be repeated)")
+	flag.StringSlice("grpcDescriptorSet", nil, "Path to a binary FileDescriptorSet with gRPC services to check (can be repeated)")
+	flag.StringSlice("grpcInclude", nil, "Only check gRPC methods matching the pattern, e.g. package.Service/* (can be repeated)")
+	flag.StringSlice("grpcExclude", nil, "Skip gRPC methods matching the pattern, e.g. package.Service/Method (can be repeated)")
+	flag.StringSlice("grpcBlockCodes", nil,
+		"gRPC status codes that WAF uses while blocking calls, e.g. PERMISSION_DENIED. If not set, gRPC codes are mapped to HTTP codes and checked against blockStatusCodes")
+	flag.StringSlice("grpcPassCodes", nil,
+		"gRPC status codes that WAF uses while passing calls, e.g. OK,NOT_FOUND. If not set, gRPC codes are mapped to HTTP codes and checked against passStatusCodes")
+	flag.String("grpcBlockRegex", "", "Regex to detect a blocked gRPC call by the status message")
+	flag.String("grpcPassRegex", "", "Regex to detect a passed gRPC call by the status message")
+	flag.StringSlice("grpcBlockMetadata", nil, "Response header metadata that WAF sets while blocking gRPC calls, in the key:regex format (can be repeated)")
+	flag.StringSlice("grpcBlockTrailers", nil, "Response trailers that WAF sets while blocking gRPC calls, in the key:regex format (can be repeated)")
+	flag.StringSlice("grpcPassMetadata", nil, "Response header metadata that WAF sets while passing gRPC calls, in the key:regex format (can be repeated)")
+	flag.StringSlice("grpcPassTrailers", nil, "Response trailers that WAF sets while passing gRPC calls, in the key:regex format (can be repeated)")
 	flag.String("proxy", "", "Proxy URL to use")
 	flag.Bool("tlsVerify", false, "If true, the received TLS certificate will be verified")
 	flag.Int("maxIdleConns", 2, "The maximum number of keep-alive connections")
@@ -92,6 +108,8 @@ func parseFlags() (args string, err error) {
 	flag.Int("randomDelay", 400, "Random delay in ms in addition to the delay between requests")
 	flag.String("testCase", "", "If set then only this test case will be run")
 	flag.String("testSet", "", "If set then only this test set's cases will be run")
+	flag.StringSlice("tags", nil, "If set then only test cases with at least one of the tags will be run (can be repeated)")
+	flag.StringSlice("excludeTags", nil, "Skip test cases with any of the tags (can be repeated)")
 	flag.String("reportPath", reportPath, "A directory to store reports")
 	reportName := flag.String("reportName", defaultReportName, "Report file name. Supports `time' package template format")
 	flag.String("reportFormat", "pdf", "Export report to one of the following formats: none, pdf, html, json")
@@ -105,6 +123,11 @@ func parseFlags() (args string, err error) {
 	flag.String("addHeader", "", "An HTTP header to add to requests")
 	flag.Bool("addDebugHeader", false, "Add header with a hash of the test information in each request")
 	flag.String("openapiFile", "", "Path to openAPI file")
+	flag.Bool("mutate", false, "If true, mutate blocked payloads to find variants that bypass the WAF")
+	mutationBudget := flag.Int("mutationBudget", 30, "The maximum number of requests sent while mutating a single blocked payload")
+	flag.Bool("probeLimits", false, "If true, find how many bytes before a blocked payload the WAF inspects in each placeholder")
+	probeMaxSize := flag.Int("probeMaxSize", 1048576, "The maximum padding in bytes while probing inspection limits")
+	probeMaxHeaders := flag.Int("probeMaxHeaders", 1000, "The maximum number of headers sent before the payload while probing inspection limits")
 	showVersion := flag.Bool("version", false, "Show GoTestWAF version and exit")
 	flag.Parse()
 
@@ -131,6 +154,16 @@ func parseFlags() (args string, err error) {
 		}
 	}
 
+	if *mutationBudget < 1 {
+		return "", errors.New("--mutationBudget must be positive")
+	}
+	if *probeMaxSize < 1 {
+		return "", errors.New("--probeMaxSize must be positive")
+	}
+	if *probeMaxHeaders < 1 {
+		return "", errors.New("--probeMaxHeaders must be positive")
+	}
+
 	logrusLogLvl, err := logrus.ParseLevel(*logLvl)
 	if err != nil {
 		return "", err
@@ -215,7 +248,7 @@ func normalizeArgs() (string, error) {
 			value = f.Value.String()
 			arg = fmt.Sprintf("--%s=%s", f.Name, value)
 
-		case "intSlice":
+		case "intSlice", "stringSlice":
 			// remove square brackets: [200,404] -> 200,404
 			value = strings.Trim(f.Value.String(), "[]")
 			arg = fmt.Sprintf("--%s=%s", f.Name, value)
@@ -19,6 +19,7 @@ import (
 	"github.com/wallarm/gotestwaf/internal/db"
 	"github.com/wallarm/gotestwaf/internal/helpers"
 	"github.com/wallarm/gotestwaf/internal/openapi"
+	"github.com/wallarm/gotestwaf/internal/payload/placeholder"
 	"github.com/wallarm/gotestwaf/internal/report"
 	"github.com/wallarm/gotestwaf/internal/scanner"
 	"github.com/wallarm/gotestwaf/internal/version"
@@ -39,6 +40,14 @@ func main() {
 		cancel()
 	}()
 
+	if len(os.Args) > 1 && os.Args[1] == testCasesCommand {
+		if err := runTestCasesCommand(logger, os.Args[2:]); err != nil {
+			logger.WithError(err).Error("caught error in testcases command")
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := run(ctx, logger); err != nil {
 		logger.WithError(err).Error("caught error in main function")
 		os.Exit(1)
@@ -65,6 +74,11 @@ func run(ctx context.Context, logger *logrus.Logger) error {
 		return errors.Wrap(err, "couldn't load config")
 	}
 
+	err = placeholder.RegisterRequestTemplates(cfg.Placeholders)
+	if err != nil {
+		return errors.Wrap(err, "couldn't register placeholders")
+	}
+
 	logger.WithField("version", version.Version).Info("GoTestWAF started")
 
 	var openapiDoc *openapi3.T
@@ -88,7 +102,7 @@ func run(ctx context.Context, logger *logrus.Logger) error {
 
 	logger.Info("Test cases loading started")
 
-	testCases, err := db.LoadTestCases(cfg)
+	testCases, err := db.LoadTestCases(logger, cfg)
 	if err != nil {
 		return errors.Wrap(err, "loading test case")
 	}
@@ -142,11 +156,30 @@ func run(ctx context.Context, logger *logrus.Logger) error {
 	s.WAFwsBlockCheck(ctx)
 	s.CheckGRPCAvailability(ctx)
 
+	err = s.GRPCBlockCheck(ctx)
+	if err != nil {
+		return err
+	}
+
 	err = s.Run(ctx)
 	if err != nil {
 		return errors.Wrap(err, "error occurred while scanning")
 	}
 
+	if cfg.Mutate {
+		err = s.Mutate(ctx)
+		if err != nil {
+			return errors.Wrap(err, "error occurred while mutating payloads")
+		}
+	}
+
+	if cfg.ProbeLimits {
+		err = s.ProbeLimits(ctx)
+		if err != nil {
+			return errors.Wrap(err, "error occurred while probing inspection limits")
+		}
+	}
+
 	_, err = os.Stat(cfg.ReportPath)
 	if os.IsNotExist(err) {
 		if makeErr := os.Mkdir(cfg.ReportPath, 0700); makeErr != nil {
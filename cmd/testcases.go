@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/wallarm/gotestwaf/internal/config"
+	"github.com/wallarm/gotestwaf/internal/db"
+	"github.com/wallarm/gotestwaf/internal/payload/placeholder"
+)
+
+const (
+	testCasesCommand = "testcases"
+
+	testCasesValidateCommand = "validate"
+	testCasesListCommand     = "list"
+
+	testCasesUsage = `Usage: %s testcases <validate|list> [OPTIONS]
+
+Commands:
+  validate    Check test case files for errors
+  list        Print test cases and the number of requests each will generate
+
+Options:
+`
+)
+
+// runTestCasesCommand runs the testcases command with the given arguments
+// (without the command name).
+func runTestCasesCommand(logger *logrus.Logger, args []string) error {
+	fs := flag.NewFlagSet(testCasesCommand, flag.ContinueOnError)
+	fs.SortFlags = false
+	fs.Usage = func() {
+		fs.SetOutput(os.Stdout)
+		fmt.Fprintf(os.Stdout, testCasesUsage, os.Args[0])
+		fs.PrintDefaults()
+	}
+
+	configPath := fs.String("configPath", defaultConfigPath, "Path to the config file with user-defined placeholders")
+	testCasesPath := fs.String("testCasesPath", filepath.Join(".", defaultTestCasesPath), "Path to a folder with test cases")
+	testSet := fs.String("testSet", "", "If set then only this test set's cases will be listed")
+	testCase := fs.String("testCase", "", "If set then only this test case will be listed")
+	tags := fs.StringSlice("tags", nil, "If set then only test cases with at least one of the tags will be listed (can be repeated)")
+	excludeTags := fs.StringSlice("excludeTags", nil, "Skip test cases with any of the tags (can be repeated)")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected one of the commands: validate, list")
+	}
+
+	if err := registerConfigPlaceholders(*configPath); err != nil {
+		return err
+	}
+
+	switch fs.Arg(0) {
+	case testCasesValidateCommand:
+		return validateTestCases(*testCasesPath)
+
+	case testCasesListCommand:
+		return listTestCases(logger, &config.Config{
+			TestCasesPath: *testCasesPath,
+			TestSet:       *testSet,
+			TestCase:      *testCase,
+			Tags:          *tags,
+			ExcludeTags:   *excludeTags,
+		})
+	}
+
+	fs.Usage()
+	return fmt.Errorf("unknown command: %s", fs.Arg(0))
+}
+
+// registerConfigPlaceholders registers user-defined placeholders from the
+// config file if it exists.
+func registerConfigPlaceholders(configPath string) error {
+	if _, err := os.Stat(configPath); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(configPath)
+
+	if err := v.ReadInConfig(); err != nil {
+		return errors.Wrap(err, "couldn't load config")
+	}
+
+	var cfg config.Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return errors.Wrap(err, "couldn't load config")
+	}
+
+	if err := placeholder.RegisterRequestTemplates(cfg.Placeholders); err != nil {
+		return errors.Wrap(err, "couldn't register placeholders")
+	}
+
+	return nil
+}
+
+// validateTestCases prints issues of the test case files. It returns an
+// error if there are any issues.
+func validateTestCases(testCasesPath string) error {
+	issues, err := db.ValidateTestCases(testCasesPath)
+	if err != nil {
+		return errors.Wrap(err, "couldn't validate test cases")
+	}
+
+	for _, issue := range issues {
+		fmt.Fprintln(os.Stdout, issue)
+	}
+
+	if len(issues) != 0 {
+		return fmt.Errorf("found %d issues in test cases", len(issues))
+	}
+
+	fmt.Fprintln(os.Stdout, "No issues found")
+
+	return nil
+}
+
+// listTestCases prints the selected test cases and the number of requests
+// each will generate.
+func listTestCases(logger *logrus.Logger, cfg *config.Config) error {
+	testCases, err := db.LoadTestCases(logger, cfg)
+	if err != nil {
+		return errors.Wrap(err, "loading test case")
+	}
+
+	sort.Slice(testCases, func(i, j int) bool {
+		if testCases[i].Set != testCases[j].Set {
+			return testCases[i].Set < testCases[j].Set
+		}
+		return testCases[i].Name < testCases[j].Name
+	})
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Test set", "Test case", "Type", "Expect", "Payloads", "Encoders", "Placeholders", "Requests"})
+
+	total := 0
+	for _, t := range testCases {
+		requests := len(t.Payloads) * len(t.Encoders) * len(t.Placeholders)
+		total += requests
+
+		table.Append([]string{
+			t.Set,
+			t.Name,
+			t.Type,
+			t.Expect,
+			fmt.Sprintf("%d", len(t.Payloads)),
+			fmt.Sprintf("%d", len(t.Encoders)),
+			fmt.Sprintf("%d", len(t.Placeholders)),
+			fmt.Sprintf("%d", requests),
+		})
+	}
+
+	table.SetFooter([]string{"", "", "", "", "", "", "Total", fmt.Sprintf("%d", total)})
+	table.Render()
+
+	return nil
+}
@@ -4,6 +4,19 @@ type Config struct {
 	URL                   string            `mapstructure:"url"`
 	WebSocketURL          string            `mapstructure:"wsURL"`
 	GRPCPort              uint16            `mapstructure:"grpcPort"`
+	GRPCProtoFiles        []string          `mapstructure:"grpcProto"`
+	GRPCImportPaths       []string          `mapstructure:"grpcImportPath"`
+	GRPCDescriptorSets    []string          `mapstructure:"grpcDescriptorSet"`
+	GRPCInclude           []string          `mapstructure:"grpcInclude"`
+	GRPCExclude           []string          `mapstructure:"grpcExclude"`
+	GRPCBlockCodes        []string          `mapstructure:"grpcBlockCodes"`
+	GRPCPassCodes         []string          `mapstructure:"grpcPassCodes"`
+	GRPCBlockRegex        string            `mapstructure:"grpcBlockRegex"`
+	GRPCPassRegex         string            `mapstructure:"grpcPassRegex"`
+	GRPCBlockMetadata     []string          `mapstructure:"grpcBlockMetadata"`
+	GRPCBlockTrailers     []string          `mapstructure:"grpcBlockTrailers"`
+	GRPCPassMetadata      []string          `mapstructure:"grpcPassMetadata"`
+	GRPCPassTrailers      []string          `mapstructure:"grpcPassTrailers"`
 	HTTPHeaders           map[string]string `mapstructure:"headers"`
 	TLSVerify             bool              `mapstructure:"tlsVerify"`
 	Proxy                 string            `mapstructure:"proxy"`
@@ -29,6 +42,8 @@ type Config struct {
 	TestCase              string            `mapstructure:"testCase"`
 	TestCasesPath         string            `mapstructure:"testCasesPath"`
 	TestSet               string            `mapstructure:"testSet"`
+	Tags                  []string          `mapstructure:"tags"`
+	ExcludeTags           []string          `mapstructure:"excludeTags"`
 	WAFName               string            `mapstructure:"wafName"`
 	IgnoreUnresolved      bool              `mapstructure:"ignoreUnresolved"`
 	BlockConnReset        bool              `mapstructure:"blockConnReset"`
@@ -36,4 +51,25 @@ type Config struct {
 	AddHeader             string            `mapstructure:"addHeader"`
 	AddDebugHeader        bool              `mapstructure:"addDebugHeader"`
 	OpenAPIFile           string            `mapstructure:"openapiFile"`
+	Mutate                bool              `mapstructure:"mutate"`
+	MutationBudget        int               `mapstructure:"mutationBudget"`
+	ProbeLimits           bool              `mapstructure:"probeLimits"`
+	ProbeMaxSize          int               `mapstructure:"probeMaxSize"`
+	ProbeMaxHeaders       int               `mapstructure:"probeMaxHeaders"`
+
+	// Placeholders are set only in the config file
+	Placeholders []*PlaceholderTemplate `mapstructure:"placeholders"`
+}
+
+// PlaceholderTemplate is a user-defined placeholder described by the HTTP
+// request template. The request is set either by the method, the path, the
+// headers and the body, or by the raw HTTP request.
+type PlaceholderTemplate struct {
+	Name     string            `mapstructure:"name"`
+	Method   string            `mapstructure:"method"`
+	Path     string            `mapstructure:"path"`
+	Headers  map[string]string `mapstructure:"headers"`
+	Body     string            `mapstructure:"body"`
+	Raw      string            `mapstructure:"raw"`
+	Encoding string            `mapstructure:"encoding"`
 }
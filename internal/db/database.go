@@ -20,6 +20,12 @@ type DB struct {
 	naTests      []*Info
 	tests        []*Case
 
+	// truePositive stores the expectation of each test case
+	truePositive map[string]map[string]bool
+
+	derivedBypasses  []*DerivedBypass
+	inspectionLimits []*InspectionLimit
+
 	scannedPaths map[string]map[string]interface{}
 
 	NumberOfTests uint
@@ -30,8 +36,9 @@ type DB struct {
 
 func NewDB(tests []*Case) (*DB, error) {
 	db := &DB{
-		counters: make(map[string]map[string]map[string]int),
-		tests:    tests,
+		counters:     make(map[string]map[string]map[string]int),
+		tests:        tests,
+		truePositive: make(map[string]map[string]bool),
 	}
 
 	var encodedCase bytes.Buffer
@@ -47,9 +54,21 @@ func NewDB(tests []*Case) (*DB, error) {
 			db.counters[test.Set][test.Name] = map[string]int{}
 		}
 
+		if _, ok := db.truePositive[test.Set]; !ok {
+			db.truePositive[test.Set] = map[string]bool{}
+		}
+		db.truePositive[test.Set][test.Name] = test.IsTruePositive
+
 		db.NumberOfTests += uint(len(test.Payloads) * len(test.Encoders) * len(test.Placeholders))
 
-		err := enc.Encode(*test)
+		// the fingerprint of the templated test case doesn't depend on
+		// the random values generated by the templates
+		fpCase := *test
+		if test.payloadTemplates != nil {
+			fpCase.Payloads = test.payloadTemplates
+		}
+
+		err := enc.Encode(fpCase)
 		if err != nil {
 			return nil, errors.Wrap(err, "couldn't encode test case")
 		}
@@ -95,6 +114,45 @@ func (db *DB) UpdateFailedTests(t *Info) {
 	db.failedTests = append(db.failedTests, t)
 }
 
+func (db *DB) UpdateDerivedBypasses(b *DerivedBypass) {
+	db.Lock()
+	defer db.Unlock()
+	db.derivedBypasses = append(db.derivedBypasses, b)
+}
+
+func (db *DB) UpdateInspectionLimits(l *InspectionLimit) {
+	db.Lock()
+	defer db.Unlock()
+	db.inspectionLimits = append(db.inspectionLimits, l)
+}
+
+// GetBlockedTruePositiveTests returns blocked tests of the true-positive
+// test sets, i.e. blocked attacks.
+func (db *DB) GetBlockedTruePositiveTests() []*Info {
+	db.Lock()
+	defer db.Unlock()
+
+	var tests []*Info
+	for _, t := range db.blockedTests {
+		if !db.isPositiveCase(t.Set, t.Case) {
+			tests = append(tests, t)
+		}
+	}
+
+	return tests
+}
+
+// isPositiveCase returns true if the payloads of the test case are expected
+// to pass (false-positive test case). The expectation is set while loading
+// the test case, and is inferred from the test set name if the test case is
+// unknown.
+func (db *DB) isPositiveCase(setName, caseName string) bool {
+	if isTruePositive, ok := db.truePositive[setName][caseName]; ok {
+		return !isTruePositive
+	}
+	return isPositiveTest(setName)
+}
+
 func (db *DB) AddToScannedPaths(method string, path string) {
 	db.Lock()
 	defer db.Unlock()
@@ -61,5 +61,16 @@ func (db *DB) ExportPayloads(payloadsExportFile string) error {
 		}
 	}
 
+	for _, derivedBypass := range db.derivedBypasses {
+		placeholder := derivedBypass.Placeholder
+		if derivedBypass.Split {
+			placeholder += " (split)"
+		}
+		err = csvWriter.Write([]string{derivedBypass.MutatedPayload, "derived bypass", strconv.Itoa(derivedBypass.ResponseStatusCode), placeholder, derivedBypass.MutatedEncoder, derivedBypass.Case})
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
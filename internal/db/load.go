@@ -6,37 +6,26 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 
 	"github.com/wallarm/gotestwaf/internal/config"
+	"github.com/wallarm/gotestwaf/internal/payload/encoder"
+	"github.com/wallarm/gotestwaf/internal/payload/placeholder"
 )
 
-func LoadTestCases(cfg *config.Config) (testCases []*Case, err error) {
-	var files []string
-
+func LoadTestCases(logger *logrus.Logger, cfg *config.Config) (testCases []*Case, err error) {
 	if cfg.TestCasesPath == "" {
 		return nil, errors.New("empty test cases path")
 	}
 
-	if err = filepath.Walk(cfg.TestCasesPath, func(path string, info os.FileInfo, err error) error {
-		files = append(files, path)
-		return nil
-	}); err != nil {
+	files, err := testCaseFiles(cfg.TestCasesPath)
+	if err != nil {
 		return nil, err
 	}
 
 	for _, testCaseFile := range files {
-		fileExt := filepath.Ext(testCaseFile)
-		if fileExt != ".yml" && fileExt != ".yaml" {
-			continue
-		}
-
-		// Ignore subdirectories, process as .../<testSetName>/<testCaseName>/<case>.yml
-		parts := strings.Split(testCaseFile, string(os.PathSeparator))
-		parts = parts[len(parts)-3:]
-
-		testSetName := parts[1]
-		testCaseName := strings.TrimSuffix(parts[2], fileExt)
+		testSetName, testCaseName := testCaseNames(testCaseFile)
 
 		if cfg.TestSet != "" && testSetName != cfg.TestSet {
 			continue
@@ -46,24 +35,65 @@ func LoadTestCases(cfg *config.Config) (testCases []*Case, err error) {
 			continue
 		}
 
-		yamlFile, err := os.ReadFile(testCaseFile)
-		if err != nil {
-			return nil, err
+		var t Case
+
+		if filepath.Ext(testCaseFile) == wordlistExt {
+			wordlistCase, err := loadWordlist(cfg.TestCasesPath, testCaseFile)
+			if err != nil {
+				return nil, err
+			}
+
+			// text files without a manifest aren't wordlists
+			if wordlistCase == nil {
+				logger.WithField("file", testCaseFile).Warn("no manifest found, the text file is ignored")
+				continue
+			}
+
+			t = *wordlistCase
+		} else {
+			yamlFile, err := os.ReadFile(testCaseFile)
+			if err != nil {
+				return nil, err
+			}
+
+			err = yaml.Unmarshal(yamlFile, &t)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if t.Template {
+			t.payloadTemplates = t.Payloads
+			t.Payloads, err = expandPayloads(t.payloadTemplates, t.Vars)
+			if err != nil {
+				return nil, errors.Wrapf(err, "couldn't load test case %s", testCaseFile)
+			}
 		}
 
-		var t Case
-		err = yaml.Unmarshal(yamlFile, &t)
-		if err != nil {
-			return nil, err
+		for i, e := range t.Encoders {
+			t.Encoders[i], err = encoder.NormalizeChain(e)
+			if err != nil {
+				return nil, errors.Wrapf(err, "couldn't load test case %s", testCaseFile)
+			}
+		}
+
+		for _, p := range t.Placeholders {
+			if _, _, err = placeholder.Lookup(p); err != nil {
+				return nil, errors.Wrapf(err, "couldn't load test case %s", testCaseFile)
+			}
 		}
 
 		t.Name = testCaseName
 		t.Set = testSetName
 
-		if strings.Contains(testSetName, "false") {
-			t.IsTruePositive = false // test case is false positive
-		} else {
-			t.IsTruePositive = true // test case is true positive
+		// the expectation is inferred from the test set name if it isn't set
+		// explicitly
+		if err = normalizeMetadata(&t); err != nil {
+			return nil, errors.Wrapf(err, "couldn't load test case %s", testCaseFile)
+		}
+
+		if !isSelectedByTags(&t, cfg.Tags, cfg.ExcludeTags) {
+			continue
 		}
 
 		testCases = append(testCases, &t)
@@ -75,3 +105,31 @@ func LoadTestCases(cfg *config.Config) (testCases []*Case, err error) {
 
 	return testCases, nil
 }
+
+// testCaseFiles returns paths of all YAML test case files and wordlists in
+// the directory and its subdirectories. Wordlist manifests are skipped.
+func testCaseFiles(root string) ([]string, error) {
+	var files []string
+
+	if err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		fileExt := filepath.Ext(path)
+		if fileExt == wordlistExt || contains(yamlExts, fileExt) && !isManifest(path) {
+			files = append(files, path)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// testCaseNames returns names of the test set and the test case of the file.
+// Subdirectories are ignored, the file is processed as
+// .../<testSetName>/<testCaseName>.yml
+func testCaseNames(path string) (testSetName, testCaseName string) {
+	testSetName = filepath.Base(filepath.Dir(path))
+	testCaseName = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	return testSetName, testCaseName
+}
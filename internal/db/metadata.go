@@ -0,0 +1,126 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// ExpectBlock means that the payloads of the test case are attacks and
+	// must be blocked by the WAF (true-positive test case).
+	ExpectBlock = "block"
+
+	// ExpectPass means that the payloads of the test case are legitimate and
+	// must be passed by the WAF (false-positive test case).
+	ExpectPass = "pass"
+)
+
+// Severities are the allowed values of the severity of the test case.
+var Severities = []string{"info", "low", "medium", "high", "critical"}
+
+var (
+	cweRe      = regexp.MustCompile(`^(?i:cwe-)?([0-9]+)$`)
+	owaspRe    = regexp.MustCompile(`^A(0[1-9]|10):20[0-9]{2}$`)
+	owaspAPIRe = regexp.MustCompile(`^API([1-9]|10):20[0-9]{2}$`)
+)
+
+// fieldError is an error in the value of the test case field.
+type fieldError struct {
+	field string
+	msg   string
+}
+
+func newFieldError(field, format string, args ...interface{}) *fieldError {
+	return &fieldError{field: field, msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *fieldError) Error() string {
+	return e.msg
+}
+
+// normalizeMetadata validates the metadata of the test case and brings it to
+// the canonical form: the expectation and the severity in lower case,
+// CWE identifiers as CWE-<number>, tags in lower case. If the expectation is
+// not set, it is inferred from the test set name.
+func normalizeMetadata(t *Case) error {
+	t.Expect = strings.ToLower(strings.TrimSpace(t.Expect))
+	switch t.Expect {
+	case "":
+		if isPositiveTest(t.Set) {
+			t.Expect = ExpectPass
+		} else {
+			t.Expect = ExpectBlock
+		}
+	case ExpectBlock, ExpectPass:
+	default:
+		return newFieldError("expect", "invalid expect value %q, must be %s or %s", t.Expect, ExpectBlock, ExpectPass)
+	}
+
+	t.IsTruePositive = t.Expect == ExpectBlock
+
+	t.Severity = strings.ToLower(strings.TrimSpace(t.Severity))
+	if t.Severity != "" && !contains(Severities, t.Severity) {
+		return newFieldError("severity", "invalid severity %q, must be one of: %s", t.Severity, strings.Join(Severities, ", "))
+	}
+
+	for i, cwe := range t.CWE {
+		m := cweRe.FindStringSubmatch(strings.TrimSpace(cwe))
+		if m == nil {
+			return newFieldError("cwe", "invalid CWE identifier %q, must be like CWE-79", cwe)
+		}
+		t.CWE[i] = "CWE-" + m[1]
+	}
+
+	for _, id := range t.OWASP {
+		if !owaspRe.MatchString(id) {
+			return newFieldError("owasp", "invalid OWASP Top 10 identifier %q, must be like A03:2021", id)
+		}
+	}
+
+	for _, id := range t.OWASPAPI {
+		if !owaspAPIRe.MatchString(id) {
+			return newFieldError("owasp_api", "invalid OWASP API Top 10 identifier %q, must be like API8:2019", id)
+		}
+	}
+
+	for i, tag := range t.Tags {
+		t.Tags[i] = strings.ToLower(strings.TrimSpace(tag))
+		if t.Tags[i] == "" {
+			return newFieldError("tags", "empty tag")
+		}
+	}
+
+	return nil
+}
+
+// isSelectedByTags returns true if the test case has at least one of the
+// tags (or the tags are empty) and has none of the excluded tags.
+func isSelectedByTags(t *Case, tags, excludeTags []string) bool {
+	for _, tag := range excludeTags {
+		if contains(t.Tags, strings.ToLower(tag)) {
+			return false
+		}
+	}
+
+	if len(tags) == 0 {
+		return true
+	}
+
+	for _, tag := range tags {
+		if contains(t.Tags, strings.ToLower(tag)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package db
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/wallarm/gotestwaf/internal/config"
+)
+
+func writeTestCase(t *testing.T, dir, set, name, content string) {
+	writeFile(t, filepath.Join(dir, set, name+".yml"), content)
+}
+
+func TestNormalizeMetadata(t *testing.T) {
+	tc := &Case{
+		Set:      "owasp",
+		Expect:   " Pass ",
+		Severity: "HIGH",
+		CWE:      []string{"79", "cwe-89"},
+		OWASP:    []string{"A03:2021"},
+		OWASPAPI: []string{"API8:2019"},
+		Tags:     []string{"XSS "},
+	}
+
+	if err := normalizeMetadata(tc); err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	if tc.Expect != ExpectPass || tc.IsTruePositive {
+		t.Fatalf("got expect %q, true positive %v", tc.Expect, tc.IsTruePositive)
+	}
+	if tc.Severity != "high" {
+		t.Fatalf("got severity %q", tc.Severity)
+	}
+	if strings.Join(tc.CWE, ",") != "CWE-79,CWE-89" {
+		t.Fatalf("got CWE %v", tc.CWE)
+	}
+	if strings.Join(tc.Tags, ",") != "xss" {
+		t.Fatalf("got tags %v", tc.Tags)
+	}
+
+	// the expectation is inferred from the test set name
+	for set, want := range map[string]bool{"owasp": true, "false-pos": false} {
+		tc = &Case{Set: set}
+		if err := normalizeMetadata(tc); err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+		if tc.IsTruePositive != want {
+			t.Fatalf("%s: got true positive %v, want %v", set, tc.IsTruePositive, want)
+		}
+	}
+}
+
+func TestNormalizeMetadataErrors(t *testing.T) {
+	for _, tc := range []*Case{
+		{Expect: "allow"},
+		{Severity: "urgent"},
+		{CWE: []string{"XSS"}},
+		{OWASP: []string{"A11:2021"}},
+		{OWASPAPI: []string{"API3"}},
+		{Tags: []string{" "}},
+	} {
+		if err := normalizeMetadata(tc); err == nil {
+			t.Fatalf("%+v: expected an error", tc)
+		}
+	}
+}
+
+func TestLoadTestCasesByTags(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, tags := range map[string]string{
+		"sqli":  "[injection, sqli]",
+		"xss":   "[injection, xss]",
+		"texts": "[]",
+	} {
+		writeTestCase(t, dir, "set", name, `---
+payload:
+  - test
+encoder:
+  - Plain
+placeholder:
+  - URLParam
+tags: `+tags+`
+...
+`)
+	}
+
+	for _, tt := range []struct {
+		tags, excludeTags []string
+		want              string
+	}{
+		{nil, nil, "sqli,texts,xss"},
+		{[]string{"injection"}, nil, "sqli,xss"},
+		{[]string{"XSS", "sqli"}, nil, "sqli,xss"},
+		{[]string{"injection"}, []string{"xss"}, "sqli"},
+		{nil, []string{"sqli"}, "texts,xss"},
+	} {
+		cases, err := LoadTestCases(newTestLogger(), &config.Config{
+			TestCasesPath: dir,
+			Tags:          tt.tags,
+			ExcludeTags:   tt.excludeTags,
+		})
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+
+		var names []string
+		for _, c := range cases {
+			names = append(names, c.Name)
+		}
+		sort.Strings(names)
+
+		if got := strings.Join(names, ","); got != tt.want {
+			t.Fatalf("tags %v, exclude %v: got %s, want %s", tt.tags, tt.excludeTags, got, tt.want)
+		}
+	}
+}
+
+func TestExplicitExpectation(t *testing.T) {
+	dir := t.TempDir()
+
+	// the legitimate payload in the true-positive test set
+	writeTestCase(t, dir, "owasp", "texts", `---
+payload:
+  - hello
+encoder:
+  - Plain
+placeholder:
+  - URLParam
+expect: pass
+...
+`)
+
+	cases, err := LoadTestCases(newTestLogger(), &config.Config{TestCasesPath: dir})
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	db, err := NewDB(cases)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	db.UpdateBlockedTests(&Info{Set: "owasp", Case: "texts", Payload: "hello"})
+
+	if tests := db.GetBlockedTruePositiveTests(); len(tests) != 0 {
+		t.Fatalf("got %d blocked true-positive tests, want 0", len(tests))
+	}
+
+	stat := db.GetStatistics(false, false)
+	if len(stat.PositiveTests.FalsePositive) != 1 || len(stat.NegativeTests.Blocked) != 0 {
+		t.Fatalf("blocked legitimate payload isn't reported as false positive")
+	}
+}
@@ -11,12 +11,93 @@ type Info struct {
 	Type               string
 }
 
+// DerivedBypass is a mutation of the blocked payload which has bypassed
+// the WAF.
+type DerivedBypass struct {
+	Payload        string
+	Encoder        string
+	Placeholder    string
+	Set            string
+	Case           string
+	Type           string
+	Mutations      []string
+	MutatedEncoder string
+
+	// MutatedPayload is the payload sent to the WAF, i.e. the result of
+	// the MutatedEncoder. It's saved because some mutations are random.
+	MutatedPayload string
+
+	// Split is true if the MutatedPayload was split across several URL
+	// parameters with the same name.
+	Split bool
+
+	ResponseStatusCode int
+	Requests           int
+}
+
+// Inspection limit probe results.
+const (
+	// LimitFound means the payload bypasses the WAF with the padding of
+	// the limit size.
+	LimitFound = "found"
+	// LimitNotReached means the payload is blocked with the maximum padding.
+	LimitNotReached = "not reached"
+	// LimitNotBlocked means the payload isn't blocked even without padding.
+	LimitNotBlocked = "not blocked"
+)
+
+// Inspection limit units.
+const (
+	LimitUnitBytes   = "bytes"
+	LimitUnitHeaders = "headers"
+)
+
+// InspectionLimit is the amount of padding before the blocked payload which
+// makes the WAF pass the payload, i.e. how much of the request location the
+// WAF inspects.
+type InspectionLimit struct {
+	Payload     string
+	Encoder     string
+	Placeholder string
+	Set         string
+	Case        string
+
+	// Unit is the unit of the padding: bytes before the payload or headers
+	// sent before the header with the payload.
+	Unit string
+
+	// Result is one of LimitFound, LimitNotReached or LimitNotBlocked.
+	Result string
+
+	// Limit is the smallest padding which makes the WAF pass the payload.
+	// It's set for the LimitFound result only.
+	Limit int
+
+	// MaxPadding is the maximum padding tried.
+	MaxPadding int
+
+	Requests int
+}
+
 type Case struct {
-	Payloads       []string `yaml:"payload"`
-	Encoders       []string `yaml:"encoder"`
-	Placeholders   []string `yaml:"placeholder"`
-	Type           string   `default:"unknown" yaml:"type"`
+	Payloads       []string               `yaml:"payload"`
+	Encoders       []string               `yaml:"encoder"`
+	Placeholders   PlaceholderList        `yaml:"placeholder"`
+	Type           string                 `default:"unknown" yaml:"type"`
+	Template       bool                   `yaml:"template"`
+	Vars           map[string]TemplateVar `yaml:"vars"`
+	Expect         string                 `yaml:"expect"`
+	Severity       string                 `yaml:"severity"`
+	CWE            []string               `yaml:"cwe"`
+	OWASP          []string               `yaml:"owasp"`
+	OWASPAPI       []string               `yaml:"owasp_api"`
+	Tags           []string               `yaml:"tags"`
+	Description    string                 `yaml:"description"`
 	Set            string
 	Name           string
 	IsTruePositive bool
+
+	// payloadTemplates are the payloads of the templated test case before
+	// the expansion. They are used to compute the fingerprint.
+	payloadTemplates []string
 }
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/wallarm/gotestwaf/internal/payload/placeholder"
+)
+
+// PlaceholderList is the list of placeholders of the test case. In YAML, each
+// item is either the placeholder name or the placeholder with options, e.g.
+// {Header: {name: User-Agent}}. The items are stored as the placeholder specs,
+// e.g. Header(name=User-Agent). An option with the list of values adds the
+// placeholder for every value, e.g. {NonCRUDHeader: {method: [PATCH, get]}}
+// adds NonCRUDHeader(method=PATCH) and NonCRUDHeader(method=get).
+type PlaceholderList []string
+
+func (l *PlaceholderList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var items []interface{}
+	if err := unmarshal(&items); err != nil {
+		return err
+	}
+
+	specs := make([]string, 0, len(items))
+	for _, item := range items {
+		itemSpecs, err := placeholderSpecs(item)
+		if err != nil {
+			return err
+		}
+		specs = append(specs, itemSpecs...)
+	}
+
+	*l = specs
+	return nil
+}
+
+// placeholderItems returns the index of the YAML list item for every
+// placeholder spec of the test case. It returns nil if the list can't be
+// parsed.
+func placeholderItems(src []byte) []int {
+	var t struct {
+		Placeholders []interface{} `yaml:"placeholder"`
+	}
+	if err := yaml.Unmarshal(src, &t); err != nil {
+		return nil
+	}
+
+	var items []int
+	for i, item := range t.Placeholders {
+		specs, err := placeholderSpecs(item)
+		if err != nil {
+			return nil
+		}
+		for range specs {
+			items = append(items, i)
+		}
+	}
+
+	return items
+}
+
+// placeholderSpecs returns the placeholder specs of the YAML list item.
+func placeholderSpecs(item interface{}) ([]string, error) {
+	switch v := item.(type) {
+	case string:
+		return []string{v}, nil
+
+	case map[interface{}]interface{}:
+		if len(v) != 1 {
+			return nil, errors.New("placeholder with options must have exactly one key, e.g. {Header: {name: User-Agent}}")
+		}
+
+		for key, value := range v {
+			name, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid placeholder name: %v", key)
+			}
+
+			if value == nil {
+				return []string{name}, nil
+			}
+
+			opts, ok := value.(map[interface{}]interface{})
+			if !ok {
+				return nil, fmt.Errorf("options of placeholder %s must be a map", name)
+			}
+
+			optionSets, err := expandOptions(opts)
+			if err != nil {
+				return nil, errors.Wrapf(err, "options of placeholder %s", name)
+			}
+
+			specs := make([]string, 0, len(optionSets))
+			for _, options := range optionSets {
+				specs = append(specs, placeholder.FormatSpec(name, options))
+			}
+
+			return specs, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid placeholder: %v", item)
+}
+
+// expandOptions returns every combination of the option values. The options
+// with a single value are the same in all combinations.
+func expandOptions(opts map[interface{}]interface{}) ([]map[string]string, error) {
+	keys := make([]string, 0, len(opts))
+	values := make(map[string][]string, len(opts))
+
+	for k, v := range opts {
+		key := fmt.Sprint(k)
+		keys = append(keys, key)
+
+		list, ok := v.([]interface{})
+		if !ok {
+			values[key] = []string{fmt.Sprint(v)}
+			continue
+		}
+
+		if len(list) == 0 {
+			return nil, fmt.Errorf("empty list of values of option %s", key)
+		}
+		for _, item := range list {
+			values[key] = append(values[key], fmt.Sprint(item))
+		}
+	}
+
+	// keys are sorted to keep the order of the specs stable
+	sort.Strings(keys)
+
+	sets := []map[string]string{make(map[string]string, len(keys))}
+	for _, key := range keys {
+		var expanded []map[string]string
+		for _, set := range sets {
+			for _, value := range values[key] {
+				options := make(map[string]string, len(keys))
+				for k, v := range set {
+					options[k] = v
+				}
+				options[key] = value
+				expanded = append(expanded, options)
+			}
+		}
+		sets = expanded
+	}
+
+	return sets, nil
+}
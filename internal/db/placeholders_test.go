@@ -0,0 +1,139 @@
+package db
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wallarm/gotestwaf/internal/config"
+)
+
+func TestLoadPlaceholderOptions(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestCase(t, dir, "set", "options", `---
+payload:
+  - "<script>"
+encoder:
+  - Plain
+placeholder:
+  - URLParam
+  - Header: {name: User-Agent}
+  - JSONBody:
+      path: user.name
+  - HTMLForm: {}
+...
+`)
+
+	cases, err := LoadTestCases(newTestLogger(), &config.Config{TestCasesPath: dir})
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if len(cases) != 1 {
+		t.Fatalf("got %d test cases, want 1", len(cases))
+	}
+
+	got := strings.Join(cases[0].Placeholders, ",")
+	want := "URLParam,Header(name=User-Agent),JSONBody(path=user.name),HTMLForm"
+	if got != want {
+		t.Fatalf("got placeholders %s, want %s", got, want)
+	}
+}
+
+func TestValidatePlaceholderOptions(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestCase(t, dir, "set", "options", `---
+payload:
+  - "<script>"
+encoder:
+  - Plain
+placeholder:
+  - Header: {name: User-Agent}
+  - Header:
+      value: a
+  - URLPath: {name: a}
+...
+`)
+
+	issues, err := ValidateTestCases(dir)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, strings.TrimPrefix(issue.String(), dir+string(filepath.Separator)))
+	}
+
+	want := []string{
+		`set/options.yml:8: placeholder Header: unknown option "value", supported: name`,
+		`set/options.yml:10: placeholder URLPath: options aren't supported, got "name"`,
+	}
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestExpandPlaceholderOptions(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestCase(t, dir, "set", "methods", `---
+payload:
+  - "<script>"
+encoder:
+  - Plain
+placeholder:
+  - NonCRUDHeader: {method: [PATCH, PROPFIND, get]}
+  - MethodOverrideParam: {method: [PUT, DELETE], name: _method}
+  - NonCrudUrlParam: {method: FOO}
+...
+`)
+
+	cases, err := LoadTestCases(newTestLogger(), &config.Config{TestCasesPath: dir})
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if len(cases) != 1 {
+		t.Fatalf("got %d test cases, want 1", len(cases))
+	}
+
+	got := strings.Join(cases[0].Placeholders, ",")
+	want := "NonCRUDHeader(method=PATCH),NonCRUDHeader(method=PROPFIND),NonCRUDHeader(method=get)," +
+		"MethodOverrideParam(method=PUT,name=_method),MethodOverrideParam(method=DELETE,name=_method)," +
+		"NonCrudUrlParam(method=FOO)"
+	if got != want {
+		t.Fatalf("got placeholders %s, want %s", got, want)
+	}
+
+	writeTestCase(t, dir, "set", "methods", `---
+payload:
+  - "<script>"
+encoder:
+  - Plain
+placeholder:
+  - NonCRUDHeader: {method: [PATCH, get]}
+  - NonCrudUrlParam: {method: [FOO, "b@d"]}
+  - NonCRUDHeader: {method: [get]}
+...
+`)
+
+	issues, err := ValidateTestCases(dir)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	var gotIssues []string
+	for _, issue := range issues {
+		gotIssues = append(gotIssues, strings.TrimPrefix(issue.String(), dir+string(filepath.Separator)))
+	}
+
+	wantIssues := []string{
+		`set/methods.yml:8: placeholder NonCrudUrlParam: invalid method "b@d"`,
+		`set/methods.yml:9: duplicate placeholder "NonCRUDHeader(method=get)", first defined at line 7`,
+	}
+
+	if strings.Join(gotIssues, "\n") != strings.Join(wantIssues, "\n") {
+		t.Fatalf("got issues:\n%s\nwant:\n%s", strings.Join(gotIssues, "\n"), strings.Join(wantIssues, "\n"))
+	}
+}
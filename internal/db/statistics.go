@@ -51,6 +51,9 @@ type Statistics struct {
 		FailedRequestsPercentage        float64
 	}
 
+	DerivedBypasses  []*DerivedBypass
+	InspectionLimits []*InspectionLimit
+
 	Score struct {
 		ApiSec struct {
 			TrueNegative float64
@@ -174,9 +177,9 @@ func (db *DB) GetStatistics(ignoreUnresolved, nonBlockedAsPassed bool) *Statisti
 		}
 		sort.Strings(sortedTestCases)
 
-		isPositive := isPositiveTest(testSet)
-
 		for _, testCase := range sortedTestCases {
+			isPositive := db.isPositiveCase(testSet, testCase)
+
 			// Number of requests for all request types for the selected testCase
 			unresolvedRequests := unresolvedRequestsNumber[testSet][testCase]
 			passedRequests := db.counters[testSet][testCase]["passed"]
@@ -280,7 +283,7 @@ func (db *DB) GetStatistics(ignoreUnresolved, nonBlockedAsPassed bool) *Statisti
 			Type:               blockedTest.Type,
 		}
 
-		if isPositiveTest(blockedTest.Set) {
+		if db.isPositiveCase(blockedTest.Set, blockedTest.Case) {
 			s.PositiveTests.FalsePositive = append(s.PositiveTests.FalsePositive, testDetails)
 		} else {
 			s.NegativeTests.Blocked = append(s.NegativeTests.Blocked, testDetails)
@@ -301,7 +304,7 @@ func (db *DB) GetStatistics(ignoreUnresolved, nonBlockedAsPassed bool) *Statisti
 			Type:               passedTest.Type,
 		}
 
-		if isPositiveTest(passedTest.Set) {
+		if db.isPositiveCase(passedTest.Set, passedTest.Case) {
 			s.PositiveTests.TruePositive = append(s.PositiveTests.TruePositive, testDetails)
 		} else {
 			s.NegativeTests.Bypasses = append(s.NegativeTests.Bypasses, testDetails)
@@ -323,13 +326,13 @@ func (db *DB) GetStatistics(ignoreUnresolved, nonBlockedAsPassed bool) *Statisti
 		}
 
 		if ignoreUnresolved || nonBlockedAsPassed {
-			if isPositiveTest(unresolvedTest.Set) {
+			if db.isPositiveCase(unresolvedTest.Set, unresolvedTest.Case) {
 				s.PositiveTests.FalsePositive = append(s.PositiveTests.FalsePositive, testDetails)
 			} else {
 				s.NegativeTests.Bypasses = append(s.NegativeTests.Bypasses, testDetails)
 			}
 		} else {
-			if isPositiveTest(unresolvedTest.Set) {
+			if db.isPositiveCase(unresolvedTest.Set, unresolvedTest.Case) {
 				s.PositiveTests.Unresolved = append(s.PositiveTests.Unresolved, testDetails)
 			} else {
 				s.NegativeTests.Unresolved = append(s.NegativeTests.Unresolved, testDetails)
@@ -348,13 +351,40 @@ func (db *DB) GetStatistics(ignoreUnresolved, nonBlockedAsPassed bool) *Statisti
 			Type:        failedTest.Type,
 		}
 
-		if isPositiveTest(failedTest.Set) {
+		if db.isPositiveCase(failedTest.Set, failedTest.Case) {
 			s.PositiveTests.Failed = append(s.PositiveTests.Failed, testDetails)
 		} else {
 			s.NegativeTests.Failed = append(s.NegativeTests.Failed, testDetails)
 		}
 	}
 
+	s.DerivedBypasses = append(s.DerivedBypasses, db.derivedBypasses...)
+	sort.Slice(s.DerivedBypasses, func(i, j int) bool {
+		a, b := s.DerivedBypasses[i], s.DerivedBypasses[j]
+		if a.Set != b.Set {
+			return a.Set < b.Set
+		}
+		if a.Case != b.Case {
+			return a.Case < b.Case
+		}
+		if a.Payload != b.Payload {
+			return a.Payload < b.Payload
+		}
+		if a.Placeholder != b.Placeholder {
+			return a.Placeholder < b.Placeholder
+		}
+		return a.Encoder < b.Encoder
+	})
+
+	s.InspectionLimits = append(s.InspectionLimits, db.inspectionLimits...)
+	sort.Slice(s.InspectionLimits, func(i, j int) bool {
+		a, b := s.InspectionLimits[i], s.InspectionLimits[j]
+		if a.Placeholder != b.Placeholder {
+			return a.Placeholder < b.Placeholder
+		}
+		return a.Unit < b.Unit
+	})
+
 	if db.scannedPaths != nil {
 		var paths ScannedPaths
 		for path, methods := range db.scannedPaths {
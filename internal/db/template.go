@@ -0,0 +1,273 @@
+package db
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// padChar is used by the pad template function.
+	padChar = "A"
+
+	randStrAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// TemplateVar is a variable of the templated test case. In YAML, it is either
+// a list of values or a range of integers, e.g. {from: 1, to: 10, step: 1}.
+type TemplateVar []string
+
+func (v *TemplateVar) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var values []string
+	if err := unmarshal(&values); err == nil {
+		*v = values
+		return nil
+	}
+
+	var r struct {
+		From int `yaml:"from"`
+		To   int `yaml:"to"`
+		Step int `yaml:"step"`
+	}
+	if err := unmarshal(&r); err != nil {
+		return errors.New("variable must be a list of values or a range, e.g. {from: 1, to: 10, step: 1}")
+	}
+
+	if r.Step == 0 {
+		r.Step = 1
+	}
+	if r.Step < 0 || r.From > r.To {
+		return fmt.Errorf("invalid range: from %d to %d with step %d", r.From, r.To, r.Step)
+	}
+
+	*v = nil
+	for i := r.From; i <= r.To; i += r.Step {
+		*v = append(*v, strconv.Itoa(i))
+	}
+
+	return nil
+}
+
+// expandPayloads executes the payload templates. Each template is executed
+// for every combination of values of the variables it refers to.
+func expandPayloads(templates []string, vars map[string]TemplateVar) ([]string, error) {
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	funcs := templateFuncs(rnd)
+
+	var payloads []string
+
+	for _, src := range templates {
+		tmpl, err := template.New("payload").
+			Funcs(funcs).
+			Option("missingkey=error").
+			Parse(src)
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't parse payload template")
+		}
+
+		names := templateVarNames(tmpl.Tree.Root)
+		for _, name := range names {
+			if _, ok := vars[name]; !ok {
+				return nil, fmt.Errorf("undefined variable %q in payload template %q", name, src)
+			}
+		}
+
+		err = forEachCombination(names, vars, func(data map[string]string) error {
+			var b strings.Builder
+			if err := tmpl.Execute(&b, data); err != nil {
+				return errors.Wrap(err, "couldn't execute payload template")
+			}
+
+			payloads = append(payloads, b.String())
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return payloads, nil
+}
+
+// forEachCombination calls f for every combination of values of the
+// variables (the cartesian product).
+func forEachCombination(names []string, vars map[string]TemplateVar, f func(data map[string]string) error) error {
+	data := make(map[string]string, len(names))
+
+	var walk func(i int) error
+	walk = func(i int) error {
+		if i == len(names) {
+			return f(data)
+		}
+
+		for _, value := range vars[names[i]] {
+			data[names[i]] = value
+			if err := walk(i + 1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return walk(0)
+}
+
+// templateVarNames returns sorted names of the variables (.name) used
+// in the template.
+func templateVarNames(root parse.Node) []string {
+	set := make(map[string]interface{})
+
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.FieldNode:
+			set[n.Ident[0]] = nil
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		}
+	}
+
+	walk(root)
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// templateFuncs returns functions available in the payload templates.
+func templateFuncs(rnd *rand.Rand) template.FuncMap {
+	return template.FuncMap{
+		// pad returns n padding characters
+		"pad": func(n interface{}) (string, error) {
+			count, err := toInt(n)
+			if err != nil {
+				return "", err
+			}
+			return strings.Repeat(padChar, count), nil
+		},
+
+		// repeat returns s repeated n times
+		"repeat": func(s string, n interface{}) (string, error) {
+			count, err := toInt(n)
+			if err != nil {
+				return "", err
+			}
+			return strings.Repeat(s, count), nil
+		},
+
+		// randInt returns a random non-negative integer. With one argument,
+		// the integer is less than it, with two arguments, the integer is
+		// in the [min, max) interval.
+		"randInt": func(args ...interface{}) (int, error) {
+			bounds := make([]int, 0, len(args))
+			for _, arg := range args {
+				i, err := toInt(arg)
+				if err != nil {
+					return 0, err
+				}
+				bounds = append(bounds, i)
+			}
+
+			switch len(bounds) {
+			case 0:
+				return rnd.Int(), nil
+			case 1:
+				if bounds[0] <= 0 {
+					return 0, fmt.Errorf("randInt: invalid max %d", bounds[0])
+				}
+				return rnd.Intn(bounds[0]), nil
+			case 2:
+				if bounds[0] >= bounds[1] {
+					return 0, fmt.Errorf("randInt: invalid interval [%d, %d)", bounds[0], bounds[1])
+				}
+				return bounds[0] + rnd.Intn(bounds[1]-bounds[0]), nil
+			}
+
+			return 0, errors.New("randInt: too many arguments")
+		},
+
+		// randStr returns a random alphanumeric string of length n
+		"randStr": func(n interface{}) (string, error) {
+			count, err := toInt(n)
+			if err != nil {
+				return "", err
+			}
+
+			b := make([]byte, count)
+			for i := range b {
+				b[i] = randStrAlphabet[rnd.Intn(len(randStrAlphabet))]
+			}
+			return string(b), nil
+		},
+	}
+}
+
+// toInt converts template function arguments to int. Values of variables
+// are strings, so they are parsed.
+func toInt(v interface{}) (int, error) {
+	var (
+		i   int
+		err error
+	)
+
+	switch n := v.(type) {
+	case int:
+		i = n
+	case string:
+		i, err = strconv.Atoi(n)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not an integer", n)
+		}
+	default:
+		return 0, fmt.Errorf("%v is not an integer", v)
+	}
+
+	if i < 0 {
+		return 0, fmt.Errorf("%d is negative", i)
+	}
+
+	return i, nil
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/wallarm/gotestwaf/internal/config"
+)
+
+func TestExpandPayloads(t *testing.T) {
+	vars := map[string]TemplateVar{
+		"size": {"1", "3"},
+		"tag":  {"a", "b"},
+	}
+
+	got, err := expandPayloads([]string{
+		"{{pad .size}}<{{.tag}}>",
+		`{{repeat "x" 2}}`,
+		"{{.size}}",
+	}, vars)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	want := []string{"A<a>", "A<b>", "AAA<a>", "AAA<b>", "xx", "1", "3"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestExpandPayloadsRandom(t *testing.T) {
+	got, err := expandPayloads([]string{"{{randStr 8}}-{{randInt 10}}-{{randInt 5 7}}"}, nil)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	if len(got) != 1 || !regexp.MustCompile(`^[a-zA-Z0-9]{8}-\d-[56]$`).MatchString(got[0]) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestExpandPayloadsErrors(t *testing.T) {
+	for _, tmpl := range []string{
+		"{{.undefined}}",
+		"{{pad}}",
+		`{{pad "x"}}`,
+		"{{randInt 5 5}}",
+		"{{unknown 1}}",
+	} {
+		if _, err := expandPayloads([]string{tmpl}, nil); err == nil {
+			t.Fatalf("%s: expected an error", tmpl)
+		}
+	}
+}
+
+func TestLoadTemplatedTestCase(t *testing.T) {
+	dir := t.TempDir()
+
+	testCase := `---
+payload:
+  - "{{pad .size}};<script>"
+encoder:
+  - Plain
+placeholder:
+  - URLParam
+  - HTMLForm
+template: true
+vars:
+  size: {from: 2, to: 6, step: 2}
+...
+`
+	if err := os.MkdirAll(filepath.Join(dir, "set"), 0700); err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "set", "case.yml"), []byte(testCase), 0600); err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	cases, err := LoadTestCases(newTestLogger(), &config.Config{TestCasesPath: dir})
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	want := []string{"AA;<script>", "AAAA;<script>", "AAAAAA;<script>"}
+	if strings.Join(cases[0].Payloads, ",") != strings.Join(want, ",") {
+		t.Fatalf("got %v, want %v", cases[0].Payloads, want)
+	}
+
+	db, err := NewDB(cases)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if db.NumberOfTests != 6 {
+		t.Fatalf("got %d tests, want 6", db.NumberOfTests)
+	}
+}
+
+func TestTemplatedTestCaseFingerprint(t *testing.T) {
+	newDB := func() *DB {
+		payloads, err := expandPayloads([]string{"{{randStr 16}}"}, nil)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+
+		db, err := NewDB([]*Case{{
+			Payloads:         payloads,
+			Encoders:         []string{"Plain"},
+			Placeholders:     []string{"URLParam"},
+			Template:         true,
+			Set:              "set",
+			Name:             "case",
+			payloadTemplates: []string{"{{randStr 16}}"},
+		}})
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+
+		return db
+	}
+
+	if first, second := newDB().Hash, newDB().Hash; first != second {
+		t.Fatalf("fingerprints differ: %s != %s", first, second)
+	}
+}
@@ -0,0 +1,351 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	yaml3 "gopkg.in/yaml.v3"
+
+	"github.com/wallarm/gotestwaf/internal/payload/encoder"
+	"github.com/wallarm/gotestwaf/internal/payload/placeholder"
+)
+
+var yamlErrLineRe = regexp.MustCompile(`line ([0-9]+): `)
+
+// Issue is a problem found in the test case file.
+type Issue struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (i *Issue) String() string {
+	if i.Line == 0 {
+		return fmt.Sprintf("%s: %s", i.File, i.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+}
+
+// ValidateTestCases checks all test case files and wordlists in the
+// directory: the YAML syntax, unknown fields, empty and duplicate payloads,
+// encoders and placeholders, names of the encoders and the placeholders,
+// payload templates and metadata. The issues are sorted by the file and the
+// line.
+func ValidateTestCases(root string) ([]*Issue, error) {
+	if root == "" {
+		return nil, errors.New("empty test cases path")
+	}
+
+	files, err := testCaseFiles(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no test case files found in %s", root)
+	}
+
+	var issues []*Issue
+
+	// the directory-level manifest is checked with each wordlist, so its
+	// issues are reported once
+	seen := make(map[Issue]interface{})
+
+	for _, file := range files {
+		var fileIssues []*Issue
+
+		if filepath.Ext(file) == wordlistExt {
+			fileIssues, err = validateWordlist(root, file)
+		} else {
+			fileIssues, err = validateTestCase(file)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, issue := range fileIssues {
+			if _, ok := seen[*issue]; !ok {
+				seen[*issue] = nil
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+		return issues[i].Line < issues[j].Line
+	})
+
+	return issues, nil
+}
+
+// validateTestCase returns issues of the YAML test case file.
+func validateTestCase(file string) ([]*Issue, error) {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &validator{file: file}
+
+	t, pos := v.parse(src)
+	if t == nil {
+		return v.issues, nil
+	}
+
+	v.checkList("payload", t.Payloads, pos.keyLine("payload"), func(i int) int {
+		return pos.itemLine("payload", i)
+	}, nil)
+	v.checkCase(t, pos, file)
+
+	return v.issues, nil
+}
+
+// validateWordlist returns issues of the wordlist and its manifest.
+func validateWordlist(root, file string) ([]*Issue, error) {
+	wv := &validator{file: file}
+
+	manifest := findManifest(root, file)
+	if manifest == "" {
+		wv.report(0, "no manifest found, the file is ignored")
+		return wv.issues, nil
+	}
+
+	src, err := os.ReadFile(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	mv := &validator{file: manifest}
+
+	t, pos := mv.parse(src)
+	if t == nil {
+		return mv.issues, nil
+	}
+
+	if len(t.Payloads) != 0 {
+		mv.report(pos.keyLine("payload"), "manifest must not contain payloads")
+	}
+
+	src, err = os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	payloads, lines, err := readWordlist(src)
+	if err != nil {
+		wv.report(0, "couldn't read wordlist: %v", err)
+		return wv.issues, nil
+	}
+
+	wv.checkList("payload", payloads, 0, func(i int) int {
+		return lines[i]
+	}, nil)
+
+	t.Payloads = payloads
+	mv.checkCase(t, pos, file)
+
+	return append(wv.issues, mv.issues...), nil
+}
+
+// validator collects issues of the file.
+type validator struct {
+	file   string
+	issues []*Issue
+}
+
+func (v *validator) report(line int, format string, args ...interface{}) {
+	v.issues = append(v.issues, &Issue{File: v.file, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// parse unmarshals the test case. Unknown fields and wrong types are
+// reported, and the rest of the file is checked as is. If the file can't be
+// parsed, nil is returned.
+func (v *validator) parse(src []byte) (*Case, *yamlPositions) {
+	var t Case
+
+	err := yaml.UnmarshalStrict(src, &t)
+	if err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			line, msg := splitYAMLError(err.Error())
+			v.report(line, "invalid YAML: %s", msg)
+			return nil, nil
+		}
+
+		for _, e := range typeErr.Errors {
+			line, msg := splitYAMLError(e)
+			v.report(line, "%s", msg)
+		}
+
+		t = Case{}
+		if err = yaml.Unmarshal(src, &t); err != nil {
+			return nil, nil
+		}
+	}
+
+	return &t, newYAMLPositions(src)
+}
+
+// checkList reports the empty list and the duplicate values, and checks each
+// value of the list.
+func (v *validator) checkList(key string, values []string, keyLine int, itemLine func(i int) int, check func(value string) error) {
+	if len(values) == 0 {
+		v.report(keyLine, "empty %s list", key)
+		return
+	}
+
+	seen := make(map[string]int, len(values))
+	for i, value := range values {
+		line := itemLine(i)
+
+		if first, ok := seen[value]; ok {
+			v.report(line, "duplicate %s %q, first defined at line %d", key, value, first)
+			continue
+		}
+		seen[value] = line
+
+		if check == nil {
+			continue
+		}
+		if err := check(value); err != nil {
+			v.report(line, "%v", err)
+		}
+	}
+}
+
+// checkCase checks the encoders, the placeholders, the payload templates and
+// the metadata of the test case loaded from the file.
+func (v *validator) checkCase(t *Case, pos *yamlPositions, file string) {
+	itemLine := func(key string) func(i int) int {
+		return func(i int) int {
+			return pos.itemLine(key, i)
+		}
+	}
+
+	v.checkList("encoder", t.Encoders, pos.keyLine("encoder"), itemLine("encoder"), func(value string) error {
+		_, err := encoder.ParseChain(value)
+		return err
+	})
+	v.checkList("placeholder", t.Placeholders, pos.keyLine("placeholder"), pos.placeholderLine, func(value string) error {
+		_, _, err := placeholder.Lookup(value)
+		return err
+	})
+
+	if t.Template {
+		if _, err := expandPayloads(t.Payloads, t.Vars); err != nil {
+			v.report(pos.keyLine("payload"), "%v", err)
+		}
+	} else if len(t.Vars) != 0 {
+		v.report(pos.keyLine("vars"), "vars are set, but template is not enabled")
+	}
+
+	t.Set, t.Name = testCaseNames(file)
+	if err := normalizeMetadata(t); err != nil {
+		line := 0
+		var fieldErr *fieldError
+		if errors.As(err, &fieldErr) {
+			line = pos.keyLine(fieldErr.field)
+		}
+		v.report(line, "%v", err)
+	}
+}
+
+// splitYAMLError extracts the line number from the YAML error message.
+func splitYAMLError(msg string) (line int, rest string) {
+	msg = strings.TrimPrefix(msg, "yaml: ")
+
+	m := yamlErrLineRe.FindStringSubmatchIndex(msg)
+	if m == nil {
+		return 0, msg
+	}
+
+	line, _ = strconv.Atoi(msg[m[2]:m[3]])
+
+	return line, msg[:m[0]] + msg[m[1]:]
+}
+
+// yamlPositions locates top-level keys and items of sequences in the YAML
+// source. Line numbers start from 1, 0 means unknown position.
+type yamlPositions struct {
+	// root is the top-level mapping of the document, it's nil if the source
+	// isn't a mapping.
+	root *yaml3.Node
+
+	// placeholderItems are indexes of the placeholder list items the
+	// placeholder specs are created from. An item with the list of option
+	// values creates several specs.
+	placeholderItems []int
+}
+
+func newYAMLPositions(src []byte) *yamlPositions {
+	p := &yamlPositions{placeholderItems: placeholderItems(src)}
+
+	var doc yaml3.Node
+	if err := yaml3.Unmarshal(src, &doc); err != nil {
+		return p
+	}
+
+	if doc.Kind == yaml3.DocumentNode && len(doc.Content) != 0 && doc.Content[0].Kind == yaml3.MappingNode {
+		p.root = doc.Content[0]
+	}
+
+	return p
+}
+
+// placeholderLine returns the line of the list item the i-th placeholder spec
+// is created from.
+func (p *yamlPositions) placeholderLine(i int) int {
+	if i < len(p.placeholderItems) {
+		i = p.placeholderItems[i]
+	}
+	return p.itemLine("placeholder", i)
+}
+
+// lookup returns the key and the value nodes of the top-level key.
+func (p *yamlPositions) lookup(key string) (keyNode, valueNode *yaml3.Node) {
+	if p.root == nil {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(p.root.Content); i += 2 {
+		if p.root.Content[i].Value == key {
+			return p.root.Content[i], p.root.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
+
+// keyLine returns the line of the top-level key.
+func (p *yamlPositions) keyLine(key string) int {
+	keyNode, _ := p.lookup(key)
+	if keyNode == nil {
+		return 0
+	}
+	return keyNode.Line
+}
+
+// itemLine returns the line of the i-th item of the sequence under the
+// top-level key. If the item isn't found, the line of the key is returned.
+func (p *yamlPositions) itemLine(key string, i int) int {
+	keyNode, valueNode := p.lookup(key)
+	if keyNode == nil {
+		return 0
+	}
+
+	if valueNode.Kind == yaml3.SequenceNode && i < len(valueNode.Content) {
+		return valueNode.Content[i].Line
+	}
+
+	return keyNode.Line
+}
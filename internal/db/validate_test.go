@@ -0,0 +1,110 @@
+package db
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateTestCases(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestCase(t, dir, "set", "valid", `---
+payload:
+  - "<script>"
+encoder:
+  - Plain
+  - URL|Base64
+placeholder:
+  - URLParam
+...
+`)
+
+	writeTestCase(t, dir, "set", "invalid", `---
+payload:
+  - "<script>"
+  - "' or 1=1"
+  - "<script>"
+encoder:
+  - Plain
+  - URL|Unknown
+placeholder: []
+severity: urgent
+unknown: field
+...
+`)
+
+	writeTestCase(t, dir, "set", "syntax", `---
+payload:
+  - "<script>"
+ encoder: Plain
+`)
+
+	issues, err := ValidateTestCases(dir)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, strings.TrimPrefix(issue.String(), dir+string(filepath.Separator)))
+	}
+
+	want := []string{
+		`set/invalid.yml:5: duplicate payload "<script>", first defined at line 3`,
+		`set/invalid.yml:8: unknown encoder "Unknown" in "URL|Unknown"`,
+		`set/invalid.yml:9: empty placeholder list`,
+		`set/invalid.yml:10: invalid severity "urgent", must be one of: info, low, medium, high, critical`,
+		`set/invalid.yml:11: field unknown not found in type db.Case`,
+		`set/syntax.yml:3: invalid YAML: did not find expected key`,
+	}
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestYAMLPositions(t *testing.T) {
+	pos := newYAMLPositions([]byte(`---
+payload:
+  # comment
+  - a
+  - |
+    multiline
+    - not an item
+  - b
+encoder: [Plain, URL]
+placeholder:
+- URLParam
+vars:
+  payload: x
+tags: [
+  a,
+  b
+]
+...
+`))
+
+	for _, tt := range []struct {
+		key  string
+		i    int
+		want int
+	}{
+		{"payload", 0, 4},
+		{"payload", 1, 5},
+		{"payload", 2, 8},
+		{"encoder", 1, 9},
+		{"placeholder", 0, 11},
+		{"placeholder", 1, 10},
+		{"tags", 1, 16},
+		{"type", 0, 0},
+	} {
+		if got := pos.itemLine(tt.key, tt.i); got != tt.want {
+			t.Fatalf("%s[%d]: got line %d, want %d", tt.key, tt.i, got, tt.want)
+		}
+	}
+
+	if got := pos.keyLine("vars"); got != 12 {
+		t.Fatalf("vars: got line %d, want 12", got)
+	}
+}
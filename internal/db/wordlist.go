@@ -0,0 +1,123 @@
+package db
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// wordlistExt is the extension of the plain-text wordlists. Each
+	// non-blank line of the wordlist is a payload.
+	wordlistExt = ".txt"
+
+	// manifestName is the name of the directory-level manifest which
+	// describes all wordlists in the directory and its subdirectories.
+	manifestName = "manifest"
+
+	// sidecarManifestSuffix is the suffix of the manifest of the single
+	// wordlist, e.g. xss.manifest.yml for xss.txt.
+	sidecarManifestSuffix = ".manifest"
+)
+
+var yamlExts = []string{".yml", ".yaml"}
+
+// isManifest returns true if the file is a wordlist manifest.
+func isManifest(path string) bool {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return name == manifestName || strings.HasSuffix(name, sidecarManifestSuffix)
+}
+
+// findManifest returns the path of the manifest of the wordlist: the sidecar
+// manifest or the nearest directory-level manifest up to the root of the test
+// cases. An empty string is returned if there is no manifest.
+func findManifest(root, wordlist string) string {
+	var candidates []string
+
+	base := strings.TrimSuffix(wordlist, filepath.Ext(wordlist))
+	for _, ext := range yamlExts {
+		candidates = append(candidates, base+sidecarManifestSuffix+ext)
+	}
+
+	root = filepath.Clean(root)
+	for dir := filepath.Dir(wordlist); ; dir = filepath.Dir(dir) {
+		for _, ext := range yamlExts {
+			candidates = append(candidates, filepath.Join(dir, manifestName+ext))
+		}
+
+		if dir == root || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+// readWordlist returns the payloads of the wordlist and the numbers of the
+// lines they are on. Blank lines are skipped.
+func readWordlist(src []byte) (payloads []string, lines []int, err error) {
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	scanner.Buffer(nil, len(src)+1)
+
+	for n := 1; scanner.Scan(); n++ {
+		payload := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.TrimSpace(payload) == "" {
+			continue
+		}
+
+		payloads = append(payloads, payload)
+		lines = append(lines, n)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return payloads, lines, nil
+}
+
+// loadWordlist loads the wordlist as the test case described by its manifest.
+// If the wordlist has no manifest, nil is returned.
+func loadWordlist(root, wordlist string) (*Case, error) {
+	manifest := findManifest(root, wordlist)
+	if manifest == "" {
+		return nil, nil
+	}
+
+	manifestFile, err := os.ReadFile(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var t Case
+	if err = yaml.Unmarshal(manifestFile, &t); err != nil {
+		return nil, errors.Wrapf(err, "couldn't load manifest %s", manifest)
+	}
+
+	if len(t.Payloads) != 0 {
+		return nil, errors.Errorf("couldn't load manifest %s: manifest must not contain payloads", manifest)
+	}
+
+	wordlistFile, err := os.ReadFile(wordlist)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Payloads, _, err = readWordlist(wordlistFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't read wordlist %s", wordlist)
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/wallarm/gotestwaf/internal/config"
+)
+
+// newTestLogger returns the logger which discards all messages.
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return logger
+}
+
+func writeFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+}
+
+func TestLoadWordlists(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "manifest.yml"), `---
+encoder:
+  - Plain
+placeholder:
+  - URLParam
+  - HTMLForm
+tags: [corpus]
+...
+`)
+	writeFile(t, filepath.Join(dir, "xss", "basic.txt"), "<script>alert(1)</script>\r\n\n  \n<img src=x onerror=alert(1)>\n")
+	writeFile(t, filepath.Join(dir, "sqli", "union.txt"), "' union select 1--\n")
+	writeFile(t, filepath.Join(dir, "sqli", "union.manifest.yml"), `---
+encoder:
+  - URL
+placeholder:
+  - URLParam
+type: "SQL Injection"
+...
+`)
+	writeFile(t, filepath.Join(dir, "legit", "texts.txt"), "hello world\n")
+	writeFile(t, filepath.Join(dir, "legit", "manifest.yaml"), `---
+encoder: [Plain]
+placeholder: [URLParam]
+expect: pass
+...
+`)
+
+	cases, err := LoadTestCases(newTestLogger(), &config.Config{TestCasesPath: dir})
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	got := make(map[string]*Case)
+	for _, c := range cases {
+		got[c.Set+"/"+c.Name] = c
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("got %d test cases, want 3", len(got))
+	}
+
+	xss := got["xss/basic"]
+	if xss == nil || strings.Join(xss.Payloads, "\n") != "<script>alert(1)</script>\n<img src=x onerror=alert(1)>" {
+		t.Fatalf("got xss test case %+v", xss)
+	}
+	if len(xss.Placeholders) != 2 || !xss.IsTruePositive || strings.Join(xss.Tags, ",") != "corpus" {
+		t.Fatalf("directory-level manifest isn't applied: %+v", xss)
+	}
+
+	sqli := got["sqli/union"]
+	if sqli == nil || sqli.Type != "SQL Injection" || strings.Join(sqli.Encoders, ",") != "URL" {
+		t.Fatalf("sidecar manifest isn't applied: %+v", sqli)
+	}
+
+	if legit := got["legit/texts"]; legit == nil || legit.IsTruePositive {
+		t.Fatalf("got legit test case %+v", legit)
+	}
+}
+
+func TestLoadWordlistWithoutManifest(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "xss", "README.txt"), "not a wordlist\n")
+	writeFile(t, filepath.Join(dir, "xss", "case.yml"), `---
+payload: ["<script>"]
+encoder: [Plain]
+placeholder: [URLParam]
+...
+`)
+
+	var logs bytes.Buffer
+
+	logger := logrus.New()
+	logger.SetOutput(&logs)
+
+	cases, err := LoadTestCases(logger, &config.Config{TestCasesPath: dir})
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	if len(cases) != 1 || cases[0].Name != "case" {
+		t.Fatalf("got %d test cases, want only case", len(cases))
+	}
+
+	if !strings.Contains(logs.String(), "level=warning") || !strings.Contains(logs.String(), "README.txt") {
+		t.Fatalf("text file without a manifest is skipped silently: %q", logs.String())
+	}
+}
+
+func TestValidateWordlists(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "xss", "manifest.yml"), `---
+encoder:
+  - Unknown
+placeholder:
+  - URLParam
+...
+`)
+	writeFile(t, filepath.Join(dir, "xss", "a.txt"), "<script>\n\n<img>\n<script>\n")
+	writeFile(t, filepath.Join(dir, "xss", "b.txt"), "<svg>\n")
+	writeFile(t, filepath.Join(dir, "misc", "notes.txt"), "notes\n")
+
+	issues, err := ValidateTestCases(dir)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, strings.TrimPrefix(issue.String(), dir+string(filepath.Separator)))
+	}
+
+	want := []string{
+		`misc/notes.txt: no manifest found, the file is ignored`,
+		`xss/a.txt:4: duplicate payload "<script>", first defined at line 1`,
+		`xss/manifest.yml:3: unknown encoder "Unknown" in "Unknown"`,
+	}
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
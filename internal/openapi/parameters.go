@@ -25,6 +25,7 @@ type allParameters struct {
 	pathParameters  map[string]*parameterSpec
 	queryParameters map[string]*parameterSpec
 	headers         map[string]*parameterSpec
+	cookies         map[string]*parameterSpec
 
 	supportedPlaceholders map[string]interface{}
 }
@@ -45,6 +46,7 @@ func parseParameters(parameters openapi3.Parameters) (*allParameters, error) {
 	pathParams := make(map[string]*parameterSpec)
 	queryParams := make(map[string]*parameterSpec)
 	headers := make(map[string]*parameterSpec)
+	cookies := make(map[string]*parameterSpec)
 	supportedPlaceholders := make(map[string]interface{})
 
 	if parameters != nil {
@@ -86,8 +88,20 @@ func parseParameters(parameters openapi3.Parameters) (*allParameters, error) {
 					supportedPlaceholders[headerPlaceholder] = nil
 				}
 
+			case openapi3.ParameterInCookie:
+				cookie, spec, err := parseCookieParameter(p.Value)
+				if err != nil {
+					return nil, err
+				}
+
+				cookies[cookie] = spec
+
+				if spec.paramType == openapi3.TypeString {
+					supportedPlaceholders[cookiePlaceholder] = nil
+				}
+
 			default:
-				return nil, fmt.Errorf("unsupported parameter place: %s", openapi3.ParameterInCookie)
+				return nil, fmt.Errorf("unsupported parameter place: %s", p.Value.In)
 			}
 		}
 	}
@@ -96,6 +110,7 @@ func parseParameters(parameters openapi3.Parameters) (*allParameters, error) {
 		pathParameters:  pathParams,
 		queryParameters: queryParams,
 		headers:         headers,
+		cookies:         cookies,
 
 		supportedPlaceholders: supportedPlaceholders,
 	}
@@ -349,6 +364,49 @@ func parseHeaderParameter(parameter *openapi3.Parameter) (paramName string, spec
 	return
 }
 
+// parseCookieParameter returns the cookie name, cookie value placeholder and
+// value type.
+func parseCookieParameter(parameter *openapi3.Parameter) (paramName string, spec *parameterSpec, err error) {
+	paramName = parameter.Name
+	spec = &parameterSpec{}
+
+	style := parameter.Style
+	if style != "" &&
+		style != openapi3.SerializationForm {
+		return "", nil, fmt.Errorf("unsupported cookie parameter style: %s", style)
+	}
+
+	if parameter.Schema == nil {
+		return "", nil, fmt.Errorf("schema not found in cookie specification")
+	}
+
+	schema := parameter.Schema.Value
+	spec.paramType = schema.Type
+	switch schema.Type {
+	case openapi3.TypeNumber:
+		randFloat := genRandomFloat(schema.Min, schema.Max, schema.ExclusiveMin, schema.ExclusiveMax)
+		spec.value = fmt.Sprintf("%f", randFloat)
+
+	case openapi3.TypeInteger:
+		randInt := genRandomInt(schema.Min, schema.Max, schema.ExclusiveMin, schema.ExclusiveMax)
+		spec.value = fmt.Sprintf("%d", randInt)
+
+	case openapi3.TypeString:
+		spec.minLength = schema.MinLength
+		if schema.MaxLength == nil {
+			spec.maxLength = math.MaxUint64
+		} else {
+			spec.maxLength = *schema.MaxLength
+		}
+		spec.value = genRandomString(spec.minLength, spec.minLength+defaultStringSize)
+
+	default:
+		return "", nil, fmt.Errorf("unsupported cookie parameter type: %s", schema.Type)
+	}
+
+	return
+}
+
 func queryParamStructParts(paramName string, queryParamStruct interface{}) []string {
 	var parts []string
 
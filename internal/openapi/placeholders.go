@@ -6,6 +6,7 @@ import (
 
 var (
 	headerPlaceholder      string
+	cookiePlaceholder      string
 	urlParamPlaceholder    string
 	urlPathPlaceholder     string
 	htmlFormPlaceholder    string
@@ -17,6 +18,7 @@ var (
 
 func init() {
 	headerPlaceholder = placeholder.DefaultHeader.GetName()
+	cookiePlaceholder = placeholder.DefaultCookie.GetName()
 	urlParamPlaceholder = placeholder.DefaultURLParam.GetName()
 	urlPathPlaceholder = placeholder.DefaultURLPath.GetName()
 	jsonBodyPlaceholder = placeholder.DefaultJSONBody.GetName()
@@ -23,6 +23,7 @@ type Template struct {
 	PathParameters        map[string]*parameterSpec
 	QueryParameters       map[string]*parameterSpec
 	Headers               map[string]*parameterSpec
+	Cookies               map[string]*parameterSpec
 	RequestBodyParameters map[string]*parameterSpec
 
 	RequestBody map[string]string
@@ -144,6 +145,7 @@ func operationTemplates(openapiDoc *openapi3.T, basePath string, path string, op
 	template.PathParameters = params.pathParameters
 	template.QueryParameters = params.queryParameters
 	template.Headers = params.headers
+	template.Cookies = params.cookies
 	template.URL = strings.TrimSuffix(basePath, "/") + path
 
 	placeholders := params.supportedPlaceholders
@@ -235,6 +237,7 @@ func (t *Template) CreateRequest(ctx context.Context, placeholder string, payloa
 	var contentType string
 	queryParams := make(map[string]string)
 	headers := make(map[string]string)
+	var cookies []string
 	path := t.URL
 
 	switch placeholder {
@@ -251,6 +254,19 @@ func (t *Template) CreateRequest(ctx context.Context, placeholder string, payloa
 			headers[header] = spec.value
 		}
 
+	case cookiePlaceholder:
+		for cookie, spec := range t.Cookies {
+			if spec.paramType == openapi3.TypeString {
+				payloadLen := uint64(len(payload))
+				if spec.minLength <= payloadLen && payloadLen <= spec.maxLength {
+					cookies = append(cookies, cookie+"="+payload)
+					continue
+				}
+			}
+
+			cookies = append(cookies, cookie+"="+spec.value)
+		}
+
 	case urlPathPlaceholder:
 		for param, spec := range t.PathParameters {
 			if spec.paramType == openapi3.TypeString {
@@ -362,6 +378,10 @@ func (t *Template) CreateRequest(ctx context.Context, placeholder string, payloa
 	for header, value := range headers {
 		req.Header.Add(header, value)
 	}
+	if len(cookies) != 0 {
+		// the value isn't sanitized as http.Request.AddCookie does
+		req.Header.Set("Cookie", strings.Join(cookies, "; "))
+	}
 
 	return req, nil
 }
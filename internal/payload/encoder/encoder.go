@@ -1,5 +1,15 @@
 package encoder
 
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ChainSeparator separates encoder names in the encoder pipeline,
+// e.g. "URL|Base64Flat|URL".
+const ChainSeparator = "|"
+
 type Encoder interface {
 	GetName() string
 	Encode(data string) (string, error)
@@ -15,12 +25,69 @@ func init() {
 	Encoders[DefaultURLEncoder.GetName()] = DefaultURLEncoder
 	Encoders[DefaultPlainEncoder.GetName()] = DefaultPlainEncoder
 	Encoders[DefaultXMLEntityEncoder.GetName()] = DefaultXMLEntityEncoder
+	Encoders[DefaultDoubleURLEncoder.GetName()] = DefaultDoubleURLEncoder
+	Encoders[DefaultTripleURLEncoder.GetName()] = DefaultTripleURLEncoder
+	Encoders[DefaultIISUnicodeEncoder.GetName()] = DefaultIISUnicodeEncoder
+	Encoders[DefaultOverlongUTF8Encoder.GetName()] = DefaultOverlongUTF8Encoder
+	Encoders[DefaultHTMLDecimalEncoder.GetName()] = DefaultHTMLDecimalEncoder
+	Encoders[DefaultHTMLHexEncoder.GetName()] = DefaultHTMLHexEncoder
+	Encoders[DefaultFullWidthEncoder.GetName()] = DefaultFullWidthEncoder
+	Encoders[DefaultSQLCommentEncoder.GetName()] = DefaultSQLCommentEncoder
+	Encoders[DefaultRandomCaseEncoder.GetName()] = DefaultRandomCaseEncoder
+	Encoders[DefaultRandomWhitespaceEncoder.GetName()] = DefaultRandomWhitespaceEncoder
+	Encoders[DefaultNullByteEncoder.GetName()] = DefaultNullByteEncoder
 }
 
+// ParseChain splits the encoder pipeline into encoders. All encoders must be
+// present in the Encoders map.
+func ParseChain(chain string) ([]Encoder, error) {
+	names := strings.Split(chain, ChainSeparator)
+	encoders := make([]Encoder, 0, len(names))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+
+		enc, ok := Encoders[name]
+		if !ok {
+			return nil, errors.Errorf("unknown encoder %q in %q", name, chain)
+		}
+
+		encoders = append(encoders, enc)
+	}
+
+	return encoders, nil
+}
+
+// NormalizeChain validates the encoder pipeline and returns its canonical
+// name without spaces around the separators.
+func NormalizeChain(chain string) (string, error) {
+	encoders, err := ParseChain(chain)
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(encoders))
+	for _, enc := range encoders {
+		names = append(names, enc.GetName())
+	}
+
+	return strings.Join(names, ChainSeparator), nil
+}
+
+// Apply encodes data with the encoder or the encoder pipeline. Encoders of
+// the pipeline are applied from left to right.
 func Apply(encoderName, data string) (string, error) {
-	ret, err := Encoders[encoderName].Encode(data)
+	encoders, err := ParseChain(encoderName)
 	if err != nil {
 		return "", err
 	}
-	return ret, nil
+
+	for _, enc := range encoders {
+		data, err = enc.Encode(data)
+		if err != nil {
+			return "", errors.Wrapf(err, "couldn't encode data with %s", enc.GetName())
+		}
+	}
+
+	return data, nil
 }
@@ -0,0 +1,113 @@
+package encoder
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyChain(t *testing.T) {
+	tests := []struct {
+		encoder string
+		data    string
+		want    string
+	}{
+		{"Plain", "<a b>", "<a b>"},
+		{"URL", "<a b>", "%3Ca+b%3E"},
+		{"URL|Base64Flat", "<a b>", "JTNDYStiJTNF"},
+		{"Base64Flat|URL", "<a b>?", "PGEgYj4%2F"},
+		{" URL | Base64Flat | URL ", "<a b>", "JTNDYStiJTNF"},
+	}
+
+	for _, test := range tests {
+		got, err := Apply(test.encoder, test.data)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+		if got != test.want {
+			t.Fatalf("%s: got %s, want %s", test.encoder, got, test.want)
+		}
+	}
+}
+
+func TestEvasionEncoders(t *testing.T) {
+	tests := []struct {
+		encoder string
+		data    string
+		want    string
+	}{
+		{"DoubleURL", "<a b>", "%253Ca%2Bb%253E"},
+		{"TripleURL", "<a>", "%25253Ca%25253E"},
+		{"IISUnicode", "<a1>", "%u003Ca1%u003E"},
+		{"IISUnicode", "a\U0001F600", "a%uD83D%uDE00"},
+		{"OverlongUTF8", "../a", "%C0%AE%C0%AE%C0%AFa"},
+		{"OverlongUTF8", "aä", "a%C3%A4"},
+		{"HTMLDecimal", "<a b>", "&#60;a&#32;b&#62;"},
+		{"HTMLHex", "<a b>", "&#x3c;a&#x20;b&#x3e;"},
+		{"FullWidth", "<a b>", "＜ａ ｂ＞"},
+		{"SQLComment", "union select 1", "/*!union*//**//*!select*//**/1"},
+		{"SQLComment", "1' OR name LIKE 'a", "1'/**//*!OR*//**/name/**//*!LIKE*//**/'a"},
+		{"SQLComment", "unions selected", "unions/**/selected"},
+		{"NullByte", "<a>", "%00<a>"},
+		{"NullByte", "<script>alert(1)</script>", "%00<s%00cript>a%00lert(1)</s%00cript>"},
+		{"NullByte", "1 OR 1=1", "%001 O%00R 1=1"},
+	}
+
+	for _, test := range tests {
+		got, err := Apply(test.encoder, test.data)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+		if got != test.want {
+			t.Fatalf("%s: got %s, want %s", test.encoder, got, test.want)
+		}
+	}
+}
+
+func TestRandomEncoders(t *testing.T) {
+	data := "union select 1, 2"
+
+	got, err := Apply("RandomCase", data)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if strings.ToLower(got) != data {
+		t.Fatalf("RandomCase: got %s, want case-insensitive %s", got, data)
+	}
+
+	// only keywords are changed
+	for i := 0; i < 10; i++ {
+		got, err = Apply("RandomCase", "<img src=Test.png>")
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+		if strings.ToLower(got) != "<img src=test.png>" || !strings.HasSuffix(got, "=Test.png>") {
+			t.Fatalf("RandomCase: got %s", got)
+		}
+	}
+
+	got, err = Apply("RandomWhitespace", data)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if strings.Join(strings.Fields(got), " ") != data || strings.Contains(got, " ") {
+		t.Fatalf("RandomWhitespace: got %q", got)
+	}
+}
+
+func TestParseChainUnknownEncoder(t *testing.T) {
+	for _, chain := range []string{"", "URL|", "URL|Foo", "URL||Plain"} {
+		if _, err := ParseChain(chain); err == nil {
+			t.Fatalf("%q: expected an error", chain)
+		}
+	}
+}
+
+func TestNormalizeChain(t *testing.T) {
+	got, err := NormalizeChain(" URL |Base64Flat| URL")
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if want := "URL|Base64Flat|URL"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package encoder
+
+import (
+	"regexp"
+	"strings"
+)
+
+// wordRegexp matches words which are checked against the keywords.
+var wordRegexp = regexp.MustCompile(`\w+`)
+
+// keywords are SQL keywords and functions, HTML tags, attributes and
+// JavaScript names which are usually looked for by the WAF signatures. They
+// are changed by the RandomCase, SQLComment and NullByte encoders.
+var keywords = map[string]struct{}{
+	// SQL
+	"all": {}, "and": {}, "benchmark": {}, "by": {}, "case": {}, "char": {},
+	"concat": {}, "delete": {}, "distinct": {}, "drop": {}, "else": {},
+	"end": {}, "exec": {}, "from": {}, "group": {}, "having": {},
+	"information_schema": {}, "insert": {}, "into": {}, "like": {},
+	"limit": {}, "not": {}, "null": {}, "or": {}, "order": {}, "select": {},
+	"sleep": {}, "table": {}, "then": {}, "union": {}, "update": {},
+	"values": {}, "waitfor": {}, "when": {}, "where": {}, "xor": {},
+
+	// HTML and JavaScript
+	"alert": {}, "body": {}, "confirm": {}, "cookie": {}, "document": {},
+	"embed": {}, "eval": {}, "href": {}, "iframe": {}, "img": {},
+	"javascript": {}, "object": {}, "onerror": {}, "onload": {},
+	"onmouseover": {}, "prompt": {}, "script": {}, "src": {}, "style": {},
+	"svg": {}, "window": {},
+}
+
+// MapKeywords returns a copy of the data with all keywords replaced by the
+// mapping function. Keywords are matched as whole words regardless of case.
+func MapKeywords(data string, mapping func(keyword string) string) string {
+	return wordRegexp.ReplaceAllStringFunc(data, func(word string) string {
+		if _, ok := keywords[strings.ToLower(word)]; ok {
+			return mapping(word)
+		}
+		return word
+	})
+}
+
+// isAlphanumeric returns true for ASCII letters and digits. The escaping
+// evasion encoders keep them as is, as sqlmap tamper scripts do, so the
+// result stays readable and can be placed into any part of the request.
+func isAlphanumeric(r rune) bool {
+	return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9'
+}
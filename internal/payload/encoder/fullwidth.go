@@ -0,0 +1,34 @@
+package encoder
+
+import (
+	"strings"
+)
+
+const (
+	// fullWidthOffset is the distance between printable ASCII characters
+	// and their full-width forms (U+FF01-U+FF5E).
+	fullWidthOffset = 0xFF01 - '!'
+)
+
+// FullWidthEncoder replaces printable ASCII characters with their full-width
+// forms, which are normalized back to ASCII by some back ends.
+type FullWidthEncoder struct {
+	name string
+}
+
+var DefaultFullWidthEncoder = FullWidthEncoder{name: "FullWidth"}
+
+var _ Encoder = (*FullWidthEncoder)(nil)
+
+func (enc FullWidthEncoder) GetName() string {
+	return enc.name
+}
+
+func (enc FullWidthEncoder) Encode(data string) (string, error) {
+	return strings.Map(func(r rune) rune {
+		if '!' <= r && r <= '~' {
+			return r + fullWidthOffset
+		}
+		return r
+	}, data), nil
+}
@@ -0,0 +1,53 @@
+package encoder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HTMLEntityEncoder replaces all characters except letters and digits with
+// the numeric HTML character references.
+type HTMLEntityEncoder struct {
+	name string
+	mode uint8
+}
+
+const (
+	HTMLEntityEncoderDecimalMode = 1
+	HTMLEntityEncoderHexMode     = 2
+)
+
+var DefaultHTMLDecimalEncoder = HTMLEntityEncoder{name: "HTMLDecimal", mode: HTMLEntityEncoderDecimalMode}
+var DefaultHTMLHexEncoder = HTMLEntityEncoder{name: "HTMLHex", mode: HTMLEntityEncoderHexMode}
+
+var _ Encoder = (*HTMLEntityEncoder)(nil)
+
+func (enc HTMLEntityEncoder) GetName() string {
+	return enc.name
+}
+
+func (enc HTMLEntityEncoder) Encode(data string) (string, error) {
+	var format string
+
+	switch enc.mode {
+	case HTMLEntityEncoderDecimalMode:
+		format = "&#%d;"
+	case HTMLEntityEncoderHexMode:
+		format = "&#x%x;"
+	default:
+		return "", fmt.Errorf("undefined encoding method")
+	}
+
+	var b strings.Builder
+
+	for _, r := range data {
+		if isAlphanumeric(r) {
+			b.WriteRune(r)
+			continue
+		}
+
+		fmt.Fprintf(&b, format, r)
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,38 @@
+package encoder
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// IISUnicodeEncoder replaces all characters except letters and digits with
+// the non-standard %uXXXX escape sequences, which are decoded by IIS.
+type IISUnicodeEncoder struct {
+	name string
+}
+
+var DefaultIISUnicodeEncoder = IISUnicodeEncoder{name: "IISUnicode"}
+
+var _ Encoder = (*IISUnicodeEncoder)(nil)
+
+func (enc IISUnicodeEncoder) GetName() string {
+	return enc.name
+}
+
+func (enc IISUnicodeEncoder) Encode(data string) (string, error) {
+	var b strings.Builder
+
+	for _, r := range data {
+		if isAlphanumeric(r) {
+			b.WriteRune(r)
+			continue
+		}
+
+		for _, c := range utf16.Encode([]rune{r}) {
+			fmt.Fprintf(&b, "%%u%04X", c)
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,28 @@
+package encoder
+
+// NullByteEncoder inserts the percent-encoded null byte into the payload: it's
+// prepended to the payload and inserted after the first letter of each SQL
+// and HTML keyword, e.g. "<script>" becomes "%00<s%00cript>". Some parsers
+// stop processing the value at the null byte or don't match the split
+// keywords, while many back ends and browsers drop null bytes.
+type NullByteEncoder struct {
+	name string
+}
+
+const nullByte = "%00"
+
+var DefaultNullByteEncoder = NullByteEncoder{name: "NullByte"}
+
+var _ Encoder = (*NullByteEncoder)(nil)
+
+func (enc NullByteEncoder) GetName() string {
+	return enc.name
+}
+
+func (enc NullByteEncoder) Encode(data string) (string, error) {
+	data = MapKeywords(data, func(keyword string) string {
+		return keyword[:1] + nullByte + keyword[1:]
+	})
+
+	return nullByte + data, nil
+}
@@ -0,0 +1,40 @@
+package encoder
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// OverlongUTF8Encoder replaces ASCII characters except letters and digits
+// with the percent-encoded overlong two-byte UTF-8 sequences, e.g. "/" becomes
+// "%C0%AF". Other characters are percent-encoded as is.
+type OverlongUTF8Encoder struct {
+	name string
+}
+
+var DefaultOverlongUTF8Encoder = OverlongUTF8Encoder{name: "OverlongUTF8"}
+
+var _ Encoder = (*OverlongUTF8Encoder)(nil)
+
+func (enc OverlongUTF8Encoder) GetName() string {
+	return enc.name
+}
+
+func (enc OverlongUTF8Encoder) Encode(data string) (string, error) {
+	var b strings.Builder
+
+	for _, r := range data {
+		switch {
+		case isAlphanumeric(r):
+			b.WriteRune(r)
+		case r < utf8.RuneSelf:
+			fmt.Fprintf(&b, "%%%02X%%%02X", 0xC0|byte(r>>6), 0x80|byte(r&0x3F))
+		default:
+			b.WriteString(url.QueryEscape(string(r)))
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,59 @@
+package encoder
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"unicode"
+)
+
+// whitespaces are used by the RandomWhitespace encoder to replace spaces.
+var whitespaces = []rune{'\t', '\n', '\v', '\f', '\r'}
+
+// RandomEncoder randomizes the payload in a way that is ignored by many
+// interpreters. The RandomCase encoder changes the case of letters of the SQL
+// and HTML keywords, e.g. "union select" becomes "uNIoN SeLecT", other words
+// are kept as is because identifiers and values may be case-sensitive. The
+// RandomWhitespace encoder replaces spaces with other whitespace characters.
+type RandomEncoder struct {
+	name string
+	mode uint8
+}
+
+const (
+	RandomEncoderCaseMode       = 1
+	RandomEncoderWhitespaceMode = 2
+)
+
+var DefaultRandomCaseEncoder = RandomEncoder{name: "RandomCase", mode: RandomEncoderCaseMode}
+var DefaultRandomWhitespaceEncoder = RandomEncoder{name: "RandomWhitespace", mode: RandomEncoderWhitespaceMode}
+
+var _ Encoder = (*RandomEncoder)(nil)
+
+func (enc RandomEncoder) GetName() string {
+	return enc.name
+}
+
+func (enc RandomEncoder) Encode(data string) (string, error) {
+	switch enc.mode {
+	case RandomEncoderCaseMode:
+		return MapKeywords(data, func(keyword string) string {
+			return strings.Map(func(r rune) rune {
+				if rand.Intn(2) == 0 {
+					return unicode.ToUpper(r)
+				}
+				return unicode.ToLower(r)
+			}, keyword)
+		}), nil
+
+	case RandomEncoderWhitespaceMode:
+		return strings.Map(func(r rune) rune {
+			if r == ' ' {
+				return whitespaces[rand.Intn(len(whitespaces))]
+			}
+			return r
+		}, data), nil
+	}
+
+	return "", fmt.Errorf("undefined encoding method")
+}
@@ -0,0 +1,29 @@
+package encoder
+
+import (
+	"strings"
+)
+
+// SQLCommentEncoder hides the SQL keywords from the WAF signatures with
+// comments: keywords are wrapped in the MySQL versioned comments, which are
+// executed by MySQL, and spaces are replaced with the inline comments, e.g.
+// "union select 1" becomes "/*!union*//**//*!select*//**/1".
+type SQLCommentEncoder struct {
+	name string
+}
+
+var DefaultSQLCommentEncoder = SQLCommentEncoder{name: "SQLComment"}
+
+var _ Encoder = (*SQLCommentEncoder)(nil)
+
+func (enc SQLCommentEncoder) GetName() string {
+	return enc.name
+}
+
+func (enc SQLCommentEncoder) Encode(data string) (string, error) {
+	data = MapKeywords(data, func(keyword string) string {
+		return "/*!" + keyword + "*/"
+	})
+
+	return strings.ReplaceAll(data, " ", "/**/"), nil
+}
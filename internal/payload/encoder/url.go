@@ -4,11 +4,17 @@ import (
 	"net/url"
 )
 
+// URLEncoder percent-encodes the payload as a URL query value. The DoubleURL
+// and TripleURL encoders repeat the encoding, e.g. "<" becomes "%253C", for
+// the back ends which decode the value several times.
 type URLEncoder struct {
-	name string
+	name   string
+	rounds int
 }
 
-var DefaultURLEncoder = URLEncoder{name: "URL"}
+var DefaultURLEncoder = URLEncoder{name: "URL", rounds: 1}
+var DefaultDoubleURLEncoder = URLEncoder{name: "DoubleURL", rounds: 2}
+var DefaultTripleURLEncoder = URLEncoder{name: "TripleURL", rounds: 3}
 
 var _ Encoder = (*URLEncoder)(nil)
 
@@ -17,5 +23,8 @@ func (enc URLEncoder) GetName() string {
 }
 
 func (enc URLEncoder) Encode(data string) (string, error) {
-	return url.QueryEscape(data), nil
+	for i := 0; i < enc.rounds; i++ {
+		data = url.QueryEscape(data)
+	}
+	return data, nil
 }
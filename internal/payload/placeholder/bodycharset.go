@@ -0,0 +1,115 @@
+package placeholder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+)
+
+// charsetParamRe matches the charset parameter of the Content-Type header.
+var charsetParamRe = regexp.MustCompile(`(?i);\s*charset=("[^"]*"|[^;]*)`)
+
+// charsets re-encode the UTF-8 request body in the charset.
+var charsets = map[string]func(b []byte) ([]byte, error){
+	"utf-16":   textEncoder(unicode.UTF16(unicode.BigEndian, unicode.UseBOM)),
+	"utf-16be": textEncoder(unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM)),
+	"utf-16le": textEncoder(unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)),
+	"utf-32":   textEncoder(utf32.UTF32(utf32.BigEndian, utf32.UseBOM)),
+	"utf-32be": textEncoder(utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM)),
+	"utf-32le": textEncoder(utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM)),
+	"ibm037":   textEncoder(charmap.CodePage037),
+	"ibm1047":  textEncoder(charmap.CodePage1047),
+	"utf-7":    utf7Encode,
+}
+
+func textEncoder(enc encoding.Encoding) func(b []byte) ([]byte, error) {
+	return func(b []byte) ([]byte, error) {
+		return enc.NewEncoder().Bytes(b)
+	}
+}
+
+// charsetNames returns names of the supported charsets.
+func charsetNames() []string {
+	var names []string
+	for name := range charsets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setCharset sets the charset parameter of the Content-Type header. The other
+// parameters are left as is. The text/plain type is used if the header isn't
+// set.
+func setCharset(contentType, charset string) string {
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+	contentType = charsetParamRe.ReplaceAllString(contentType, "")
+	return strings.TrimRight(contentType, " \t;") + "; charset=" + charset
+}
+
+// utf7Encode encodes the body in UTF-7 (RFC 2152). All characters except
+// letters, digits and the RFC 2152 direct characters are encoded, e.g.
+// <script> is encoded as +ADw-script+AD4-.
+func utf7Encode(b []byte) ([]byte, error) {
+	if !utf8.Valid(b) {
+		return nil, errors.New("body isn't valid UTF-8")
+	}
+
+	var out bytes.Buffer
+	var run []rune
+
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+
+		units := utf16.Encode(run)
+		buf := make([]byte, 2*len(units))
+		for i, u := range units {
+			binary.BigEndian.PutUint16(buf[2*i:], u)
+		}
+
+		out.WriteByte('+')
+		out.WriteString(base64.RawStdEncoding.EncodeToString(buf))
+		out.WriteByte('-')
+
+		run = run[:0]
+	}
+
+	for _, r := range string(b) {
+		switch {
+		case utf7Direct(r):
+			flush()
+			out.WriteRune(r)
+
+		case r == '+':
+			flush()
+			out.WriteString("+-")
+
+		default:
+			run = append(run, r)
+		}
+	}
+	flush()
+
+	return out.Bytes(), nil
+}
+
+// utf7Direct returns true if the character is written as is in UTF-7.
+func utf7Direct(r rune) bool {
+	return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' ||
+		strings.ContainsRune("'(),-./:? \t\r\n", r)
+}
@@ -0,0 +1,318 @@
+package placeholder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	charsetOption        = "charset"
+	compressOption       = "compress"
+	chunkedOption        = "chunked"
+	chunkExtensionOption = "chunkExtension"
+
+	// brotliMaxBlockLen is the maximum length of the uncompressed meta-block
+	// with the 4-nibble length.
+	brotliMaxBlockLen = 1 << 16
+)
+
+// bodyModifierOptions are the options accepted by every placeholder which
+// sends the request body. They are applied to the body created by the
+// placeholder.
+var bodyModifierOptions = []string{charsetOption, compressOption, chunkedOption, chunkExtensionOption}
+
+// compressors compress the request body with the Content-Encoding.
+var compressors = map[string]func(b []byte) ([]byte, error){
+	"gzip":    gzipCompress,
+	"deflate": zlibCompress,
+	"br":      brotliStore,
+}
+
+// bodyModifier is the placeholder with the modified request body: re-encoded
+// in the charset, compressed with the Content-Encoding or sent with the
+// chunked transfer encoding.
+type bodyModifier struct {
+	Placeholder
+
+	charset        string
+	compress       string
+	chunkSize      int
+	chunkExtension string
+}
+
+var _ Placeholder = (*bodyModifier)(nil)
+
+// ChunkedBody is the request body already encoded with the chunked transfer
+// encoding. It is used for chunk extensions, which net/http can't send, and
+// must be written to the connection as is.
+type ChunkedBody struct {
+	*bytes.Reader
+}
+
+func (b *ChunkedBody) Close() error {
+	return nil
+}
+
+// newBodyModifier extracts the body modifier options. It returns the
+// placeholder as is if there are no such options.
+func newBodyModifier(ph Placeholder, options map[string]string) (Placeholder, map[string]string, error) {
+	rest := make(map[string]string)
+	m := &bodyModifier{Placeholder: ph}
+	modified := false
+
+	for key, value := range options {
+		switch key {
+		case charsetOption:
+			value = strings.ToLower(value)
+			if _, ok := charsets[value]; !ok {
+				return nil, nil, fmt.Errorf("unknown charset %q, must be one of: %s", value, strings.Join(charsetNames(), ", "))
+			}
+			m.charset = value
+
+		case compressOption:
+			if _, ok := compressors[value]; !ok {
+				return nil, nil, fmt.Errorf("unknown compression %q, must be one of: gzip, deflate, br", value)
+			}
+			m.compress = value
+
+		case chunkedOption:
+			size, err := strconv.Atoi(value)
+			if err != nil || size < 1 {
+				return nil, nil, fmt.Errorf("invalid chunk size %q", value)
+			}
+			m.chunkSize = size
+
+		case chunkExtensionOption:
+			if value == "" || strings.ContainsAny(value, "\r\n") {
+				return nil, nil, fmt.Errorf("invalid chunk extension %q", value)
+			}
+			m.chunkExtension = value
+
+		default:
+			rest[key] = value
+			continue
+		}
+
+		modified = true
+	}
+
+	if !modified {
+		return ph, options, nil
+	}
+
+	if m.chunkExtension != "" && m.chunkSize == 0 {
+		return nil, nil, fmt.Errorf("%s requires %s", chunkExtensionOption, chunkedOption)
+	}
+
+	if len(rest) == 0 {
+		rest = nil
+	}
+
+	return m, rest, nil
+}
+
+// checkBody returns an error if the placeholder doesn't send the request
+// body, so the body modifier can't be applied.
+func checkBody(ph Placeholder, config Config) error {
+	req, err := ph.CreateRequest("http://localhost/", "test", config)
+	if err != nil || req == nil || req.Body == nil || req.Body == http.NoBody {
+		return fmt.Errorf("options %s can't be used, the placeholder doesn't send a body",
+			strings.Join(bodyModifierOptions, ", "))
+	}
+	return nil
+}
+
+func (m *bodyModifier) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	req, err := m.Placeholder.CreateRequest(requestURL, payload, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Body == nil {
+		return req, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't read request body")
+	}
+	req.Body.Close()
+
+	if m.charset != "" {
+		body, err = charsets[m.charset](body)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't encode request body in %s", m.charset)
+		}
+		req.Header.Set("Content-Type", setCharset(req.Header.Get("Content-Type"), m.charset))
+	}
+
+	if m.compress != "" {
+		body, err = compressors[m.compress](body)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't compress request body with %s", m.compress)
+		}
+		req.Header.Set("Content-Encoding", m.compress)
+	}
+
+	switch {
+	case m.chunkExtension != "":
+		chunked := encodeChunked(body, m.chunkSize, m.chunkExtension)
+		req.Body = &ChunkedBody{Reader: bytes.NewReader(chunked)}
+		req.ContentLength = -1
+		req.TransferEncoding = []string{"chunked"}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return &ChunkedBody{Reader: bytes.NewReader(chunked)}, nil
+		}
+
+	case m.chunkSize != 0:
+		// the transport writes every read of the body as a separate chunk
+		req.Body = io.NopCloser(&chunkReader{r: bytes.NewReader(body), size: m.chunkSize})
+		req.ContentLength = -1
+		req.TransferEncoding = []string{"chunked"}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(&chunkReader{r: bytes.NewReader(body), size: m.chunkSize}), nil
+		}
+
+	default:
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	return req, nil
+}
+
+// chunkReader reads at most size bytes at once.
+type chunkReader struct {
+	r    io.Reader
+	size int
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(p) > r.size {
+		p = p[:r.size]
+	}
+	return r.r.Read(p)
+}
+
+// encodeChunked encodes the body with the chunked transfer encoding. Every
+// chunk has the extension, e.g. 4;ext=value.
+func encodeChunked(body []byte, size int, extension string) []byte {
+	var b bytes.Buffer
+
+	for len(body) > 0 {
+		n := size
+		if n > len(body) {
+			n = len(body)
+		}
+
+		fmt.Fprintf(&b, "%x;%s\r\n", n, extension)
+		b.Write(body[:n])
+		b.WriteString("\r\n")
+
+		body = body[n:]
+	}
+	b.WriteString("0\r\n\r\n")
+
+	return b.Bytes()
+}
+
+func gzipCompress(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// zlibCompress compresses the body for the deflate Content-Encoding, which is
+// the zlib format (RFC 1950).
+func zlibCompress(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := zlib.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// brotliStore encodes the body as the brotli stream (RFC 7932) of the
+// uncompressed meta-blocks. The stream is valid for any brotli decoder, while
+// the body isn't readable without decoding.
+func brotliStore(b []byte) ([]byte, error) {
+	var w bitWriter
+
+	// WBITS = 16
+	w.writeBits(0, 1)
+
+	for len(b) > 0 {
+		n := brotliMaxBlockLen
+		if n > len(b) {
+			n = len(b)
+		}
+
+		w.writeBits(0, 1)          // ISLAST
+		w.writeBits(0, 2)          // MNIBBLES = 4
+		w.writeBits(uint(n-1), 16) // MLEN - 1
+		w.writeBits(1, 1)          // ISUNCOMPRESSED
+		w.writeBytes(b[:n])
+
+		b = b[n:]
+	}
+
+	w.writeBits(1, 1) // ISLAST
+	w.writeBits(1, 1) // ISLASTEMPTY
+	w.align()
+
+	return w.buf, nil
+}
+
+// bitWriter writes bits starting from the least significant bit of the byte.
+type bitWriter struct {
+	buf  []byte
+	nbit uint
+}
+
+func (w *bitWriter) writeBits(v uint, n uint) {
+	for i := uint(0); i < n; i++ {
+		if w.nbit%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		if v&(1<<i) != 0 {
+			w.buf[len(w.buf)-1] |= 1 << (w.nbit % 8)
+		}
+		w.nbit++
+	}
+}
+
+// align pads the last byte with zero bits.
+func (w *bitWriter) align() {
+	w.nbit = uint(len(w.buf)) * 8
+}
+
+// writeBytes writes the bytes from the byte boundary.
+func (w *bitWriter) writeBytes(b []byte) {
+	w.buf = append(w.buf, b...)
+	w.align()
+}
@@ -0,0 +1,188 @@
+package placeholder
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http/httputil"
+	"strings"
+	"testing"
+)
+
+func TestBodyModifier(t *testing.T) {
+	payload := `<script>alert(1)</script>`
+
+	for _, test := range []struct {
+		spec   string
+		decode func(r io.Reader) (io.Reader, error)
+	}{
+		{"JSONBody(compress=gzip)", func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		{"XMLBody(compress=deflate)", func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) }},
+	} {
+		req, err := Apply("http://example.com", test.spec, payload)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+
+		r, err := test.decode(req.Body)
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", test.spec, err)
+		}
+		body, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", test.spec, err)
+		}
+		if string(body) != payload {
+			t.Fatalf("%s: got body %q", test.spec, body)
+		}
+	}
+
+	req, err := Apply("http://example.com", "RequestBody(chunked=4)", payload)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if req.ContentLength != -1 || len(req.TransferEncoding) != 1 || req.TransferEncoding[0] != "chunked" {
+		t.Fatalf("got Content-Length %d and Transfer-Encoding %v", req.ContentLength, req.TransferEncoding)
+	}
+	buf := make([]byte, 100)
+	if n, _ := req.Body.Read(buf); n != 4 {
+		t.Fatalf("got chunk of %d bytes, want 4", n)
+	}
+
+	req, err = Apply("http://example.com", `HTMLForm(chunked=8,chunkExtension="a=b",compress=gzip,name=q)`, payload)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if _, ok := req.Body.(*ChunkedBody); !ok || req.Header.Get("Content-Encoding") != "gzip" {
+		t.Fatalf("got body %T and Content-Encoding %q", req.Body, req.Header.Get("Content-Encoding"))
+	}
+	raw, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if !bytes.HasPrefix(raw, []byte("8;a=b\r\n")) || !bytes.HasSuffix(raw, []byte("\r\n0\r\n\r\n")) {
+		t.Fatalf("got chunked body %q", raw)
+	}
+	gz, err := gzip.NewReader(httputil.NewChunkedReader(bufio.NewReader(bytes.NewReader(raw))))
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	body, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if string(body) != "q="+payload {
+		t.Fatalf("got body %q", body)
+	}
+
+	for _, spec := range []string{
+		"URLParam(compress=gzip)",
+		"Header(chunked=1,name=a)",
+		"JSONBody(compress=zstd)",
+		"JSONBody(chunked=0)",
+		"JSONBody(chunkExtension=a)",
+	} {
+		if _, _, err = Lookup(spec); err == nil {
+			t.Fatalf("%s: expected an error", spec)
+		}
+	}
+}
+
+func TestBrotliStore(t *testing.T) {
+	for _, test := range []struct {
+		data string
+		want []byte
+	}{
+		{"", []byte{0x06}},
+		{"abc", []byte{0x20, 0x00, 0x10, 'a', 'b', 'c', 0x03}},
+	} {
+		got, err := brotliStore([]byte(test.data))
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+		if !bytes.Equal(got, test.want) {
+			t.Fatalf("%q: got % x, want % x", test.data, got, test.want)
+		}
+	}
+
+	data := strings.Repeat("a", brotliMaxBlockLen+1)
+	got, err := brotliStore([]byte(data))
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	// two meta-blocks with 3-byte headers, the stream header bit shares the
+	// first byte and the last empty meta-block takes one byte
+	if len(got) != len(data)+3+3+1 {
+		t.Fatalf("got stream of %d bytes", len(got))
+	}
+}
+
+func TestLookupAll(t *testing.T) {
+	for name := range Placeholders {
+		if _, _, err := Lookup(name); err != nil {
+			t.Fatalf("%s: got an error while testing: %v", name, err)
+		}
+	}
+}
+
+func TestBodyCharset(t *testing.T) {
+	payload := `<script>`
+
+	for _, test := range []struct {
+		spec        string
+		contentType string
+		body        []byte
+	}{
+		{"JSONBody(charset=utf-16le)", "application/json; charset=utf-16le", []byte("<\x00s\x00c\x00r\x00i\x00p\x00t\x00>\x00")},
+		{"RequestBody(charset=UTF-16)", "text/plain; charset=utf-16", []byte("\xfe\xff\x00<\x00s\x00c\x00r\x00i\x00p\x00t\x00>")},
+		{"XMLBody(charset=utf-32be)", "text/xml; charset=utf-32be", []byte("\x00\x00\x00<\x00\x00\x00s\x00\x00\x00c\x00\x00\x00r\x00\x00\x00i\x00\x00\x00p\x00\x00\x00t\x00\x00\x00>")},
+		{"RequestBody(charset=ibm037)", "text/plain; charset=ibm037", []byte{0x4c, 0xa2, 0x83, 0x99, 0x89, 0x97, 0xa3, 0x6e}},
+		{"RequestBody(charset=utf-7)", "text/plain; charset=utf-7", []byte("+ADw-script+AD4-")},
+	} {
+		req, err := Apply("http://example.com", test.spec, payload)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+
+		if req.Header.Get("Content-Type") != test.contentType {
+			t.Fatalf("%s: got Content-Type %q, want %q", test.spec, req.Header.Get("Content-Type"), test.contentType)
+		}
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+		if !bytes.Equal(body, test.body) {
+			t.Fatalf("%s: got body % x, want % x", test.spec, body, test.body)
+		}
+	}
+
+	utf7, err := utf7Encode([]byte("a+b=<é>"))
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if string(utf7) != "a+-b+AD0APADpAD4-" {
+		t.Fatalf("got UTF-7 %q", utf7)
+	}
+
+	for _, test := range [][2]string{
+		{"", "text/plain; charset=utf-16le"},
+		{`text/html; charset="iso-8859-1"; a=b`, "text/html; a=b; charset=utf-16le"},
+	} {
+		if got := setCharset(test[0], "utf-16le"); got != test[1] {
+			t.Fatalf("%q: got Content-Type %q, want %q", test[0], got, test[1])
+		}
+	}
+
+	for _, spec := range []string{"JSONBody(charset=koi8-r)", "URLParam(charset=utf-7)"} {
+		if _, _, err = Lookup(spec); err == nil {
+			t.Fatalf("%s: expected an error", spec)
+		}
+	}
+
+	if _, err = Apply("http://example.com", "RequestBody(charset=ibm037)", "€"); err == nil {
+		t.Fatalf("expected an error for the character not in the charset")
+	}
+}
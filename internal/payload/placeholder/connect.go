@@ -0,0 +1,108 @@
+package placeholder
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/wallarm/gotestwaf/internal/payload/placeholder/grpc"
+)
+
+type Connect struct {
+	name string
+}
+
+var DefaultConnect = Connect{name: "Connect"}
+
+// connectCodes maps the Connect error codes to the gRPC status codes.
+var connectCodes = map[string]codes.Code{
+	"canceled":            codes.Canceled,
+	"unknown":             codes.Unknown,
+	"invalid_argument":    codes.InvalidArgument,
+	"deadline_exceeded":   codes.DeadlineExceeded,
+	"not_found":           codes.NotFound,
+	"already_exists":      codes.AlreadyExists,
+	"permission_denied":   codes.PermissionDenied,
+	"resource_exhausted":  codes.ResourceExhausted,
+	"failed_precondition": codes.FailedPrecondition,
+	"aborted":             codes.Aborted,
+	"out_of_range":        codes.OutOfRange,
+	"unimplemented":       codes.Unimplemented,
+	"internal":            codes.Internal,
+	"unavailable":         codes.Unavailable,
+	"data_loss":           codes.DataLoss,
+	"unauthenticated":     codes.Unauthenticated,
+}
+
+var _ Placeholder = (*Connect)(nil)
+var _ ResponseDecoder = (*Connect)(nil)
+
+func (p Connect) GetName() string {
+	return p.name
+}
+
+func (p Connect) NewConfig(options map[string]string) (Config, error) {
+	return nil, checkOptions(options)
+}
+
+// CreateRequest sends the payload in the ServiceFooBar.foo request message using
+// the unary Connect protocol with the binary protobuf codec.
+func (p Connect) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	reqURL, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, err
+	}
+	reqURL.Path = strings.TrimSuffix(reqURL.Path, "/") + grpcMethodPath
+
+	msg, err := proto.Marshal(&pb.Request{Value: payload})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", reqURL.String(), bytes.NewReader(msg))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/proto")
+	req.Header.Set("Connect-Protocol-Version", "1")
+
+	return req, nil
+}
+
+// DecodeResponse gets the status from the Connect error in the JSON response
+// body. Successful responses contain the binary protobuf message.
+func (p Connect) DecodeResponse(statusCode int, header http.Header, body []byte) (*status.Status, error) {
+	contentType := header.Get("Content-Type")
+
+	if statusCode == http.StatusOK && strings.HasPrefix(contentType, "application/proto") {
+		return status.New(codes.OK, ""), nil
+	}
+
+	if !strings.HasPrefix(contentType, "application/json") {
+		return nil, nil
+	}
+
+	var connectErr struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+
+	// the response is sent by something else than the Connect server
+	if err := json.Unmarshal(body, &connectErr); err != nil || connectErr.Code == "" {
+		return nil, nil
+	}
+
+	// the HTTP status code is checked if the error code is unknown
+	code, ok := connectCodes[connectErr.Code]
+	if !ok {
+		return nil, nil
+	}
+
+	return status.New(code, connectErr.Message), nil
+}
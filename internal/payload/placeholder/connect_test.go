@@ -0,0 +1,42 @@
+package placeholder
+
+import (
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestConnectDecodeResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		contentType string
+		body        string
+		code        codes.Code
+		hasStatus   bool
+	}{
+		{"message", http.StatusOK, "application/proto", "", codes.OK, true},
+		{"permission denied", http.StatusForbidden, "application/json", `{"code":"permission_denied","message":"blocked"}`, codes.PermissionDenied, true},
+		{"canceled", http.StatusRequestTimeout, "application/json", `{"code":"canceled"}`, codes.Canceled, true},
+		{"unknown code", http.StatusForbidden, "application/json", `{"code":"blocked_by_waf"}`, codes.OK, false},
+		{"not Connect error", http.StatusForbidden, "application/json", `{"error":"blocked"}`, codes.OK, false},
+		{"HTML page", http.StatusForbidden, "text/html", "<html></html>", codes.OK, false},
+	}
+
+	for _, tt := range tests {
+		header := http.Header{"Content-Type": []string{tt.contentType}}
+
+		st, err := DefaultConnect.DecodeResponse(tt.statusCode, header, []byte(tt.body))
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", tt.name, err)
+		}
+
+		if (st != nil) != tt.hasStatus {
+			t.Fatalf("%s: got status %v", tt.name, st)
+		}
+		if st != nil && st.Code() != tt.code {
+			t.Fatalf("%s: got code %s, want %s", tt.name, st.Code(), tt.code)
+		}
+	}
+}
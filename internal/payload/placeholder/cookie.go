@@ -0,0 +1,121 @@
+package placeholder
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// Cookie places the payload into the value of the cookie. The name of the
+// cookie is random or set by the name option.
+type Cookie struct {
+	name string
+}
+
+// CookieHeader places the payload as the whole value of the Cookie header.
+type CookieHeader struct {
+	name string
+}
+
+// CookiePollution places the payload into the cookie which follows the cookie
+// with the same name and a harmless value, e.g. Cookie: a=1234; a=<payload>.
+// WAFs often check only the first cookie with the name, while some back ends
+// use the last one.
+type CookiePollution struct {
+	name string
+}
+
+var DefaultCookie = Cookie{name: "Cookie"}
+var DefaultCookieHeader = CookieHeader{name: "CookieHeader"}
+var DefaultCookiePollution = CookiePollution{name: "CookiePollution"}
+
+var _ Placeholder = (*Cookie)(nil)
+var _ Placeholder = (*CookieHeader)(nil)
+var _ Placeholder = (*CookiePollution)(nil)
+
+// CookieConfig is the configuration of the Cookie and CookiePollution
+// placeholders.
+type CookieConfig struct {
+	// Name is the name of the cookie. If it's empty, the random name is used.
+	Name string
+}
+
+func (p Cookie) GetName() string {
+	return p.name
+}
+
+func (p Cookie) NewConfig(options map[string]string) (Config, error) {
+	return newNameConfig(options, func(name string) Config {
+		return &CookieConfig{Name: name}
+	})
+}
+
+func (p Cookie) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	name, err := cookieName(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCookieRequest(requestURL, name+"="+payload)
+}
+
+func (p CookieHeader) GetName() string {
+	return p.name
+}
+
+func (p CookieHeader) NewConfig(options map[string]string) (Config, error) {
+	return nil, checkOptions(options)
+}
+
+func (p CookieHeader) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	return newCookieRequest(requestURL, payload)
+}
+
+func (p CookiePollution) GetName() string {
+	return p.name
+}
+
+func (p CookiePollution) NewConfig(options map[string]string) (Config, error) {
+	return newNameConfig(options, func(name string) Config {
+		return &CookieConfig{Name: name}
+	})
+}
+
+func (p CookiePollution) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	name, err := cookieName(config)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCookieRequest(requestURL, name+"="+value+"; "+name+"="+payload)
+}
+
+// cookieName returns the configured name of the cookie or the random one.
+func cookieName(config Config) (string, error) {
+	if conf, ok := config.(*CookieConfig); ok {
+		return conf.Name, nil
+	}
+
+	return RandomHex(Seed)
+}
+
+// newCookieRequest creates the GET request with the raw value of the Cookie
+// header. The value isn't sanitized as http.Request.AddCookie does.
+func newCookieRequest(requestURL, cookie string) (*http.Request, error) {
+	reqURL, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Cookie", cookie)
+
+	return req, nil
+}
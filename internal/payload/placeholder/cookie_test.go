@@ -0,0 +1,40 @@
+package placeholder
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestCookie(t *testing.T) {
+	tests := []struct {
+		placeholder string
+		payload     string
+		cookie      string
+	}{
+		{"Cookie", "' or 1=1; --", `^[a-f0-9]{10}=' or 1=1; --$`},
+		{"Cookie(name=session)", "<script>", `^session=<script>$`},
+		{"CookieHeader", "a=<script>; b", `^a=<script>; b$`},
+		{"CookiePollution", "<script>", `^([a-f0-9]{10})=[a-f0-9]{10}; ([a-f0-9]{10})=<script>$`},
+		{"CookiePollution(name=id)", "<script>", `^id=[a-f0-9]{10}; id=<script>$`},
+	}
+
+	for _, test := range tests {
+		req, err := Apply("http://example.com/?a=b", test.placeholder, test.payload)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+
+		cookie := req.Header.Get("Cookie")
+		match := regexp.MustCompile(test.cookie).FindStringSubmatch(cookie)
+		if match == nil {
+			t.Fatalf("%s: got cookie %q, want %s", test.placeholder, cookie, test.cookie)
+		}
+		if len(match) == 3 && match[1] != match[2] {
+			t.Fatalf("%s: got different cookie names in %q", test.placeholder, cookie)
+		}
+	}
+
+	if _, _, err := Lookup("CookieHeader(name=a)"); err == nil {
+		t.Fatalf("expected an error for the CookieHeader options")
+	}
+}
@@ -1,19 +1,65 @@
 package placeholder
 
-import "net/http"
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
 
 type GRPC struct {
-	name string
+	name     string
+	metadata bool
 }
 
-var DefaultGRPC = GRPC{name: "gRPC"}
+// gRPC placeholders are handled by the gRPC client, so they don't create
+// HTTP requests.
+var (
+	DefaultGRPC               = GRPC{name: "gRPC"}
+	DefaultGRPCMetadata       = GRPC{name: "gRPCMetadata", metadata: true}
+	DefaultGRPCBinaryMetadata = GRPC{name: "gRPCBinaryMetadata", metadata: true}
+	DefaultGRPCClientStream   = GRPC{name: "gRPCClientStream"}
+	DefaultGRPCBidiStream     = GRPC{name: "gRPCBidiStream"}
+)
 
 var _ Placeholder = (*GRPC)(nil)
 
+// grpcMetadataKeyRegexp matches valid gRPC metadata keys.
+var grpcMetadataKeyRegexp = regexp.MustCompile(`^[0-9a-z_.-]+$`)
+
+// GRPCMetadataConfig is the configuration of the gRPCMetadata and
+// gRPCBinaryMetadata placeholders.
+type GRPCMetadataConfig struct {
+	// Name is the metadata key. If it's empty, the random x-<hex> key is
+	// used.
+	Name string
+}
+
 func (enc GRPC) GetName() string {
 	return enc.name
 }
 
-func (enc GRPC) CreateRequest(string, string) (*http.Request, error) {
+func (enc GRPC) NewConfig(options map[string]string) (Config, error) {
+	if !enc.metadata {
+		return nil, checkOptions(options)
+	}
+
+	config, err := newNameConfig(options, func(name string) Config {
+		// gRPC sends metadata keys in lowercase
+		return &GRPCMetadataConfig{Name: strings.ToLower(name)}
+	})
+	if err != nil || config == nil {
+		return nil, err
+	}
+
+	name := config.(*GRPCMetadataConfig).Name
+	if !grpcMetadataKeyRegexp.MatchString(name) || strings.HasPrefix(name, "grpc-") {
+		return nil, fmt.Errorf("invalid metadata key %q", name)
+	}
+
+	return config, nil
+}
+
+func (enc GRPC) CreateRequest(string, string, Config) (*http.Request, error) {
 	return nil, nil
 }
@@ -20,6 +20,8 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ServiceFooBarClient interface {
 	Foo(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+	FooClientStream(ctx context.Context, opts ...grpc.CallOption) (ServiceFooBar_FooClientStreamClient, error)
+	FooBidiStream(ctx context.Context, opts ...grpc.CallOption) (ServiceFooBar_FooBidiStreamClient, error)
 }
 
 type serviceFooBarClient struct {
@@ -39,11 +41,78 @@ func (c *serviceFooBarClient) Foo(ctx context.Context, in *Request, opts ...grpc
 	return out, nil
 }
 
+func (c *serviceFooBarClient) FooClientStream(ctx context.Context, opts ...grpc.CallOption) (ServiceFooBar_FooClientStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ServiceFooBar_ServiceDesc.Streams[0], "/encoder.ServiceFooBar/fooClientStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceFooBarFooClientStreamClient{stream}
+	return x, nil
+}
+
+type ServiceFooBar_FooClientStreamClient interface {
+	Send(*Request) error
+	CloseAndRecv() (*Response, error)
+	grpc.ClientStream
+}
+
+type serviceFooBarFooClientStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceFooBarFooClientStreamClient) Send(m *Request) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *serviceFooBarFooClientStreamClient) CloseAndRecv() (*Response, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Response)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceFooBarClient) FooBidiStream(ctx context.Context, opts ...grpc.CallOption) (ServiceFooBar_FooBidiStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ServiceFooBar_ServiceDesc.Streams[1], "/encoder.ServiceFooBar/fooBidiStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceFooBarFooBidiStreamClient{stream}
+	return x, nil
+}
+
+type ServiceFooBar_FooBidiStreamClient interface {
+	Send(*Request) error
+	Recv() (*Response, error)
+	grpc.ClientStream
+}
+
+type serviceFooBarFooBidiStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceFooBarFooBidiStreamClient) Send(m *Request) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *serviceFooBarFooBidiStreamClient) Recv() (*Response, error) {
+	m := new(Response)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ServiceFooBarServer is the server API for ServiceFooBar service.
 // All implementations must embed UnimplementedServiceFooBarServer
 // for forward compatibility
 type ServiceFooBarServer interface {
 	Foo(context.Context, *Request) (*Response, error)
+	FooClientStream(ServiceFooBar_FooClientStreamServer) error
+	FooBidiStream(ServiceFooBar_FooBidiStreamServer) error
 	mustEmbedUnimplementedServiceFooBarServer()
 }
 
@@ -54,6 +123,12 @@ type UnimplementedServiceFooBarServer struct {
 func (UnimplementedServiceFooBarServer) Foo(context.Context, *Request) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Foo not implemented")
 }
+func (UnimplementedServiceFooBarServer) FooClientStream(ServiceFooBar_FooClientStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method FooClientStream not implemented")
+}
+func (UnimplementedServiceFooBarServer) FooBidiStream(ServiceFooBar_FooBidiStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method FooBidiStream not implemented")
+}
 func (UnimplementedServiceFooBarServer) mustEmbedUnimplementedServiceFooBarServer() {}
 
 // UnsafeServiceFooBarServer may be embedded to opt out of forward compatibility for this service.
@@ -85,6 +160,58 @@ func _ServiceFooBar_Foo_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServiceFooBar_FooClientStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceFooBarServer).FooClientStream(&serviceFooBarFooClientStreamServer{stream})
+}
+
+type ServiceFooBar_FooClientStreamServer interface {
+	SendAndClose(*Response) error
+	Recv() (*Request, error)
+	grpc.ServerStream
+}
+
+type serviceFooBarFooClientStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceFooBarFooClientStreamServer) SendAndClose(m *Response) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *serviceFooBarFooClientStreamServer) Recv() (*Request, error) {
+	m := new(Request)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ServiceFooBar_FooBidiStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceFooBarServer).FooBidiStream(&serviceFooBarFooBidiStreamServer{stream})
+}
+
+type ServiceFooBar_FooBidiStreamServer interface {
+	Send(*Response) error
+	Recv() (*Request, error)
+	grpc.ServerStream
+}
+
+type serviceFooBarFooBidiStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceFooBarFooBidiStreamServer) Send(m *Response) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *serviceFooBarFooBidiStreamServer) Recv() (*Request, error) {
+	m := new(Request)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ServiceFooBar_ServiceDesc is the grpc.ServiceDesc for ServiceFooBar service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -97,6 +224,18 @@ var ServiceFooBar_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ServiceFooBar_Foo_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "fooClientStream",
+			Handler:       _ServiceFooBar_FooClientStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "fooBidiStream",
+			Handler:       _ServiceFooBar_FooBidiStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "service.proto",
 }
@@ -0,0 +1,172 @@
+package placeholder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/wallarm/gotestwaf/internal/payload/placeholder/grpc"
+)
+
+const (
+	// grpcMethodPath is the path of the built-in ServiceFooBar.foo method.
+	grpcMethodPath = "/encoder.ServiceFooBar/foo"
+
+	grpcFrameHeaderLen = 5
+	grpcTrailerFlag    = 0x80
+)
+
+type GRPCWeb struct {
+	name string
+	text bool
+}
+
+var DefaultGRPCWeb = GRPCWeb{name: "gRPCWeb"}
+var DefaultGRPCWebText = GRPCWeb{name: "gRPCWebText", text: true}
+
+var _ Placeholder = (*GRPCWeb)(nil)
+var _ ResponseDecoder = (*GRPCWeb)(nil)
+
+func (p GRPCWeb) GetName() string {
+	return p.name
+}
+
+func (p GRPCWeb) NewConfig(options map[string]string) (Config, error) {
+	return nil, checkOptions(options)
+}
+
+// CreateRequest sends the payload in the ServiceFooBar.foo request message using
+// the gRPC-Web protocol. The text variant encodes the message frame with base64.
+func (p GRPCWeb) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	reqURL, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, err
+	}
+	reqURL.Path = strings.TrimSuffix(reqURL.Path, "/") + grpcMethodPath
+
+	msg, err := proto.Marshal(&pb.Request{Value: payload})
+	if err != nil {
+		return nil, err
+	}
+
+	body := make([]byte, grpcFrameHeaderLen, grpcFrameHeaderLen+len(msg))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(msg)))
+	body = append(body, msg...)
+
+	contentType := "application/grpc-web+proto"
+	if p.text {
+		body = []byte(base64.StdEncoding.EncodeToString(body))
+		contentType = "application/grpc-web-text+proto"
+	}
+
+	req, err := http.NewRequest("POST", reqURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", contentType)
+	req.Header.Set("X-Grpc-Web", "1")
+
+	return req, nil
+}
+
+// DecodeResponse gets the gRPC status from the trailer frame of the response
+// body or, in case of a trailers-only response, from the response headers.
+func (p GRPCWeb) DecodeResponse(statusCode int, header http.Header, body []byte) (*status.Status, error) {
+	if p.text && len(body) != 0 && strings.HasPrefix(header.Get("Content-Type"), "application/grpc-web-text") {
+		var err error
+
+		body, err = decodeGRPCWebText(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	trailer := make(http.Header)
+
+	for len(body) >= grpcFrameHeaderLen {
+		flag := body[0]
+		length := int(binary.BigEndian.Uint32(body[1:grpcFrameHeaderLen]))
+		body = body[grpcFrameHeaderLen:]
+
+		if len(body) < length {
+			return nil, fmt.Errorf("gRPC-Web frame is truncated: %d bytes expected, %d received", length, len(body))
+		}
+
+		if flag&grpcTrailerFlag != 0 {
+			for _, line := range strings.Split(string(body[:length]), "\r\n") {
+				kv := strings.SplitN(line, ":", 2)
+				if len(kv) == 2 {
+					trailer.Add(textproto.TrimString(kv[0]), textproto.TrimString(kv[1]))
+				}
+			}
+		}
+
+		body = body[length:]
+	}
+
+	if trailer.Get("Grpc-Status") == "" {
+		trailer = header
+	}
+
+	return grpcStatusFromHeader(trailer)
+}
+
+// grpcStatusFromHeader returns the status encoded in the grpc-status and
+// grpc-message headers, or nil if there is no status.
+func grpcStatusFromHeader(header http.Header) (*status.Status, error) {
+	rawCode := header.Get("Grpc-Status")
+	if rawCode == "" {
+		return nil, nil
+	}
+
+	code, err := strconv.ParseUint(rawCode, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grpc-status: %s", rawCode)
+	}
+
+	msg, err := url.PathUnescape(header.Get("Grpc-Message"))
+	if err != nil {
+		msg = header.Get("Grpc-Message")
+	}
+
+	return status.New(codes.Code(code), msg), nil
+}
+
+// decodeGRPCWebText decodes the gRPC-Web text response. The server may encode
+// each frame separately, so the body may consist of several padded base64 chunks.
+func decodeGRPCWebText(body []byte) ([]byte, error) {
+	var decoded []byte
+
+	chunks := bytes.SplitAfter(bytes.TrimSpace(body), []byte("="))
+	for i := 0; i < len(chunks); i++ {
+		chunk := chunks[i]
+		// collect padding characters of the chunk
+		for i+1 < len(chunks) && bytes.Equal(chunks[i+1], []byte("=")) {
+			chunk = append(chunk, '=')
+			i++
+		}
+
+		if len(chunk) == 0 {
+			continue
+		}
+
+		data, err := base64.StdEncoding.DecodeString(string(chunk))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode gRPC-Web text response: %v", err)
+		}
+		decoded = append(decoded, data...)
+	}
+
+	return decoded, nil
+}
@@ -1,8 +1,11 @@
 package placeholder
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
+
+	"golang.org/x/net/http/httpguts"
 )
 
 type Header struct {
@@ -13,26 +16,62 @@ var DefaultHeader = Header{name: "Header"}
 
 var _ Placeholder = (*Header)(nil)
 
+// HeaderConfig is the configuration of the Header placeholder.
+type HeaderConfig struct {
+	// Name is the name of the header. If it's empty, the random X-<hex>
+	// name is used.
+	Name string
+}
+
 func (p Header) GetName() string {
 	return p.name
 }
 
-func (p Header) CreateRequest(requestURL, payload string) (*http.Request, error) {
-	reqURL, err := url.Parse(requestURL)
-	if err != nil {
+func (p Header) NewConfig(options map[string]string) (Config, error) {
+	if options == nil {
+		return nil, nil
+	}
+
+	if err := checkOptions(options, "name"); err != nil {
 		return nil, err
 	}
 
-	randomName, err := RandomHex(Seed)
+	if !httpguts.ValidHeaderFieldName(options["name"]) {
+		return nil, fmt.Errorf("invalid header name %q", options["name"])
+	}
+
+	return &HeaderConfig{Name: options["name"]}, nil
+}
+
+func (p Header) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	reqURL, err := url.Parse(requestURL)
 	if err != nil {
 		return nil, err
 	}
 
-	randomHeader := "X-" + randomName
+	var header string
+	if conf, ok := config.(*HeaderConfig); ok {
+		header = conf.Name
+	} else {
+		randomName, err := RandomHex(Seed)
+		if err != nil {
+			return nil, err
+		}
+
+		header = "X-" + randomName
+	}
+
 	req, err := http.NewRequest("GET", reqURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add(randomHeader, payload)
+
+	// the Host header is sent from the request field
+	if http.CanonicalHeaderKey(header) == "Host" {
+		req.Host = payload
+	} else {
+		req.Header.Add(header, payload)
+	}
+
 	return req, nil
 }
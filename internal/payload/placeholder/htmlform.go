@@ -14,22 +14,40 @@ var DefaultHTMLForm = HTMLForm{name: "HTMLForm"}
 
 var _ Placeholder = (*HTMLForm)(nil)
 
+// HTMLFormConfig is the configuration of the HTMLForm placeholder.
+type HTMLFormConfig struct {
+	// Name is the name of the form field. If it's empty, the random name is
+	// used.
+	Name string
+}
+
 func (p HTMLForm) GetName() string {
 	return p.name
 }
 
-func (p HTMLForm) CreateRequest(requestURL, payload string) (*http.Request, error) {
+func (p HTMLForm) NewConfig(options map[string]string) (Config, error) {
+	return newNameConfig(options, func(name string) Config {
+		return &HTMLFormConfig{Name: name}
+	})
+}
+
+func (p HTMLForm) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
 	reqURL, err := url.Parse(requestURL)
 	if err != nil {
 		return nil, err
 	}
 
-	randomName, err := RandomHex(Seed)
-	if err != nil {
-		return nil, err
+	var name string
+	if conf, ok := config.(*HTMLFormConfig); ok {
+		name = url.QueryEscape(conf.Name)
+	} else {
+		name, err = RandomHex(Seed)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	bodyPayload := randomName + "=" + payload
+	bodyPayload := name + "=" + payload
 	req, err := http.NewRequest("POST", reqURL.String(), strings.NewReader(bodyPayload))
 	if err != nil {
 		return nil, err
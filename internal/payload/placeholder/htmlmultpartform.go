@@ -15,25 +15,44 @@ var DefaultHTMLMultipartForm = HTMLMultipartForm{name: "HTMLMultipartForm"}
 
 var _ Placeholder = (*HTMLMultipartForm)(nil)
 
+// HTMLMultipartFormConfig is the configuration of the HTMLMultipartForm
+// placeholder.
+type HTMLMultipartFormConfig struct {
+	// Name is the name of the form field. If it's empty, the random name is
+	// used.
+	Name string
+}
+
 func (p HTMLMultipartForm) GetName() string {
 	return p.name
 }
 
-func (p HTMLMultipartForm) CreateRequest(requestURL, payload string) (*http.Request, error) {
+func (p HTMLMultipartForm) NewConfig(options map[string]string) (Config, error) {
+	return newNameConfig(options, func(name string) Config {
+		return &HTMLMultipartFormConfig{Name: name}
+	})
+}
+
+func (p HTMLMultipartForm) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
 	reqURL, err := url.Parse(requestURL)
 	if err != nil {
 		return nil, err
 	}
 
-	randomName, err := RandomHex(Seed)
-	if err != nil {
-		return nil, err
+	var name string
+	if conf, ok := config.(*HTMLMultipartFormConfig); ok {
+		name = conf.Name
+	} else {
+		name, err = RandomHex(Seed)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
-	fw, err := writer.CreateFormField(randomName)
+	fw, err := writer.CreateFormField(name)
 	if err != nil {
 		return nil, err
 	}
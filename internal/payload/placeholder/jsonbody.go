@@ -1,35 +1,99 @@
 package placeholder
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
 )
 
+// JSONBody sends the payload as the JSON body. The content type confusion
+// variants send the JSON body with the Content-Type of the URL-encoded form or
+// the plain text, which WAFs may not parse as JSON while some back ends do.
 type JSONBody struct {
-	name string
+	name        string
+	contentType string
 }
 
-var DefaultJSONBody = JSONBody{name: "JSONBody"}
+var DefaultJSONBody = JSONBody{name: "JSONBody", contentType: "application/json"}
+var DefaultJSONFormURLEncoded = JSONBody{name: "JSONFormURLEncoded", contentType: "application/x-www-form-urlencoded"}
+var DefaultJSONTextPlain = JSONBody{name: "JSONTextPlain", contentType: "text/plain"}
 
 var _ Placeholder = (*JSONBody)(nil)
 
+// JSONBodyConfig is the configuration of the JSONBody, JSONFormURLEncoded and
+// JSONTextPlain placeholders.
+type JSONBodyConfig struct {
+	// Path is the path to the string field with the payload in the JSON
+	// object, e.g. [user name] for {"user": {"name": "<payload>"}}. If it's
+	// empty, the payload is sent as the whole body.
+	Path []string
+}
+
 func (p JSONBody) GetName() string {
 	return p.name
 }
 
-func (p JSONBody) CreateRequest(requestURL, payload string) (*http.Request, error) {
+func (p JSONBody) NewConfig(options map[string]string) (Config, error) {
+	if options == nil {
+		return nil, nil
+	}
+
+	if err := checkOptions(options, "path"); err != nil {
+		return nil, err
+	}
+
+	path := strings.Split(options["path"], ".")
+	for _, key := range path {
+		if key == "" {
+			return nil, fmt.Errorf("invalid path %q", options["path"])
+		}
+	}
+
+	return &JSONBodyConfig{Path: path}, nil
+}
+
+func (p JSONBody) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
 	reqURL, err := url.Parse(requestURL)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", reqURL.String(), strings.NewReader(payload))
+	body := payload
+	if conf, ok := config.(*JSONBodyConfig); ok {
+		body, err = nestedJSON(conf.Path, payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest("POST", reqURL.String(), strings.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Content-Type", p.contentType)
 
 	return req, nil
 }
+
+// nestedJSON returns the JSON object with the value at the path, e.g.
+// {"user": {"name": "<value>"}} for the [user name] path.
+func nestedJSON(path []string, value string) (string, error) {
+	var obj interface{} = value
+	for i := len(path) - 1; i >= 0; i-- {
+		obj = map[string]interface{}{path[i]: obj}
+	}
+
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(obj); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
@@ -0,0 +1,301 @@
+package placeholder
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultJSONNestedDepth = 10
+	maxJSONNestedDepth     = 1000
+
+	jsonDuplicateKeyFirst = "first"
+	jsonDuplicateKeyLast  = "last"
+)
+
+// JSONNested places the payload into the string field of the deeply nested
+// JSON object, e.g. {"a":{"b":{"c":"<payload>"}}}.
+type JSONNested struct {
+	name string
+}
+
+// JSONArray places the payload into the element of the array in the JSON
+// object, e.g. {"a":[1,"b","<payload>"]}.
+type JSONArray struct {
+	name string
+}
+
+// JSONKey places the payload into the key of the JSON object, e.g.
+// {"<payload>":"a"}.
+type JSONKey struct {
+	name string
+}
+
+// JSONNumericString places the payload into the string field whose value
+// starts with digits, e.g. {"a":"12345<payload>"}. Some WAFs treat such
+// values as numbers and don't check them.
+type JSONNumericString struct {
+	name string
+}
+
+// JSONDuplicateKey places the payload into one of two fields with the same
+// key, e.g. {"a":"b","a":"<payload>"}. JSON parsers differ in which value of
+// the duplicated key they use.
+type JSONDuplicateKey struct {
+	name string
+}
+
+// JSONUnicodeKey places the payload into the field whose key is written with
+// Unicode escapes, e.g. {"\u0061":"<payload>"}.
+type JSONUnicodeKey struct {
+	name string
+}
+
+var DefaultJSONNested = JSONNested{name: "JSONNested"}
+var DefaultJSONArray = JSONArray{name: "JSONArray"}
+var DefaultJSONKey = JSONKey{name: "JSONKey"}
+var DefaultJSONNumericString = JSONNumericString{name: "JSONNumericString"}
+var DefaultJSONDuplicateKey = JSONDuplicateKey{name: "JSONDuplicateKey"}
+var DefaultJSONUnicodeKey = JSONUnicodeKey{name: "JSONUnicodeKey"}
+
+var _ Placeholder = (*JSONNested)(nil)
+var _ Placeholder = (*JSONArray)(nil)
+var _ Placeholder = (*JSONKey)(nil)
+var _ Placeholder = (*JSONNumericString)(nil)
+var _ Placeholder = (*JSONDuplicateKey)(nil)
+var _ Placeholder = (*JSONUnicodeKey)(nil)
+
+// JSONNestedConfig is the configuration of the JSONNested placeholder.
+type JSONNestedConfig struct {
+	// Depth is the number of the nested objects.
+	Depth int
+}
+
+// JSONDuplicateKeyConfig is the configuration of the JSONDuplicateKey
+// placeholder.
+type JSONDuplicateKeyConfig struct {
+	// Position is the position of the field with the payload: first or last.
+	Position string
+}
+
+// JSONUnicodeKeyConfig is the configuration of the JSONUnicodeKey
+// placeholder.
+type JSONUnicodeKeyConfig struct {
+	// Name is the key of the field. If it's empty, the random key is used.
+	Name string
+}
+
+func (p JSONNested) GetName() string {
+	return p.name
+}
+
+func (p JSONNested) NewConfig(options map[string]string) (Config, error) {
+	if options == nil {
+		return nil, nil
+	}
+
+	if err := checkOptions(options, "depth"); err != nil {
+		return nil, err
+	}
+
+	depth, err := strconv.Atoi(options["depth"])
+	if err != nil || depth < 1 || depth > maxJSONNestedDepth {
+		return nil, fmt.Errorf("invalid depth %q, must be from 1 to %d", options["depth"], maxJSONNestedDepth)
+	}
+
+	return &JSONNestedConfig{Depth: depth}, nil
+}
+
+func (p JSONNested) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	depth := defaultJSONNestedDepth
+	if conf, ok := config.(*JSONNestedConfig); ok {
+		depth = conf.Depth
+	}
+
+	var b strings.Builder
+	for i := 0; i < depth; i++ {
+		key, err := RandomHex(Seed)
+		if err != nil {
+			return nil, err
+		}
+
+		b.WriteString(`{"` + key + `":`)
+	}
+	b.WriteString(`"` + jsonEscape(payload) + `"`)
+	b.WriteString(strings.Repeat("}", depth))
+
+	return newJSONRequest(requestURL, b.String())
+}
+
+func (p JSONArray) GetName() string {
+	return p.name
+}
+
+func (p JSONArray) NewConfig(options map[string]string) (Config, error) {
+	return nil, checkOptions(options)
+}
+
+func (p JSONArray) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	key, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf(`{"%s":[1,"%s","%s"]}`, key, value, jsonEscape(payload))
+
+	return newJSONRequest(requestURL, body)
+}
+
+func (p JSONKey) GetName() string {
+	return p.name
+}
+
+func (p JSONKey) NewConfig(options map[string]string) (Config, error) {
+	return nil, checkOptions(options)
+}
+
+func (p JSONKey) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	value, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf(`{"%s":"%s"}`, jsonEscape(payload), value)
+
+	return newJSONRequest(requestURL, body)
+}
+
+func (p JSONNumericString) GetName() string {
+	return p.name
+}
+
+func (p JSONNumericString) NewConfig(options map[string]string) (Config, error) {
+	return nil, checkOptions(options)
+}
+
+func (p JSONNumericString) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	key, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	// the random number, e.g. 123456789
+	digits, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+	number, err := strconv.ParseUint(digits, 16, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf(`{"%s":"%d%s"}`, key, number, jsonEscape(payload))
+
+	return newJSONRequest(requestURL, body)
+}
+
+func (p JSONDuplicateKey) GetName() string {
+	return p.name
+}
+
+func (p JSONDuplicateKey) NewConfig(options map[string]string) (Config, error) {
+	if options == nil {
+		return nil, nil
+	}
+
+	if err := checkOptions(options, "position"); err != nil {
+		return nil, err
+	}
+
+	position := options["position"]
+	if position != jsonDuplicateKeyFirst && position != jsonDuplicateKeyLast {
+		return nil, fmt.Errorf("invalid position %q, must be %s or %s", position, jsonDuplicateKeyFirst, jsonDuplicateKeyLast)
+	}
+
+	return &JSONDuplicateKeyConfig{Position: position}, nil
+}
+
+func (p JSONDuplicateKey) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	key, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []string{value, jsonEscape(payload)}
+	if conf, ok := config.(*JSONDuplicateKeyConfig); ok && conf.Position == jsonDuplicateKeyFirst {
+		fields[0], fields[1] = fields[1], fields[0]
+	}
+
+	body := fmt.Sprintf(`{"%s":"%s","%s":"%s"}`, key, fields[0], key, fields[1])
+
+	return newJSONRequest(requestURL, body)
+}
+
+func (p JSONUnicodeKey) GetName() string {
+	return p.name
+}
+
+func (p JSONUnicodeKey) NewConfig(options map[string]string) (Config, error) {
+	return newNameConfig(options, func(name string) Config {
+		return &JSONUnicodeKeyConfig{Name: name}
+	})
+}
+
+func (p JSONUnicodeKey) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	var key string
+	if conf, ok := config.(*JSONUnicodeKeyConfig); ok {
+		key = conf.Name
+	} else {
+		var err error
+		key, err = RandomHex(Seed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	body := fmt.Sprintf(`{"%s":"%s"}`, unicodeEscape(key), jsonEscape(payload))
+
+	return newJSONRequest(requestURL, body)
+}
+
+// unicodeEscape escapes every character of the string with the \uXXXX
+// sequence. The characters outside the BMP are escaped with surrogate pairs.
+func unicodeEscape(s string) string {
+	var b strings.Builder
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, `\u%04x`, u)
+	}
+	return b.String()
+}
+
+// newJSONRequest creates the POST request with the JSON body.
+func newJSONRequest(requestURL, body string) (*http.Request, error) {
+	reqURL, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", reqURL.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create request")
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	return req, nil
+}
@@ -0,0 +1,75 @@
+package placeholder
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"testing"
+)
+
+func TestJSONInjection(t *testing.T) {
+	payload := `"<script>\alert(1)` + "\n"
+
+	tests := []struct {
+		placeholder string
+		body        string
+	}{
+		{"JSONNested", `^(\{"[a-f0-9]{10}":){10}"\\"<script>\\\\alert\(1\)\\n"\}{10}$`},
+		{"JSONNested(depth=2)", `^\{"[a-f0-9]{10}":\{"[a-f0-9]{10}":"\\"<script>\\\\alert\(1\)\\n"\}\}$`},
+		{"JSONArray", `^\{"[a-f0-9]{10}":\[1,"[a-f0-9]{10}","\\"<script>\\\\alert\(1\)\\n"\]\}$`},
+		{"JSONKey", `^\{"\\"<script>\\\\alert\(1\)\\n":"[a-f0-9]{10}"\}$`},
+		{"JSONNumericString", `^\{"[a-f0-9]{10}":"[0-9]+\\"<script>\\\\alert\(1\)\\n"\}$`},
+		{"JSONDuplicateKey", `^\{"([a-f0-9]{10})":"[a-f0-9]{10}","([a-f0-9]{10})":"\\"<script>\\\\alert\(1\)\\n"\}$`},
+		{"JSONDuplicateKey(position=first)", `^\{"([a-f0-9]{10})":"\\"<script>\\\\alert\(1\)\\n","([a-f0-9]{10})":"[a-f0-9]{10}"\}$`},
+		{"JSONUnicodeKey(name=a😀)", `^\{"\\u0061\\ud83d\\ude00":"\\"<script>\\\\alert\(1\)\\n"\}$`},
+	}
+
+	for _, test := range tests {
+		req, err := Apply("http://example.com", test.placeholder, payload)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+		body := string(b)
+
+		if req.Header.Get("Content-Type") != "application/json" || !json.Valid(b) {
+			t.Fatalf("%s: got invalid JSON request %q", test.placeholder, body)
+		}
+
+		match := regexp.MustCompile(test.body).FindStringSubmatch(body)
+		if match == nil {
+			t.Fatalf("%s: got body %s, want %s", test.placeholder, body, test.body)
+		}
+		if len(match) == 3 && match[1] != match[2] {
+			t.Fatalf("%s: got different keys in %s", test.placeholder, body)
+		}
+	}
+
+	req, err := Apply("http://example.com", "JSONUnicodeKey(name=user)", payload)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	var obj map[string]string
+	if err = json.NewDecoder(req.Body).Decode(&obj); err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if obj["user"] != payload {
+		t.Fatalf("got object %v", obj)
+	}
+
+	for _, spec := range []string{
+		"JSONNested(depth=0)",
+		"JSONNested(depth=a)",
+		"JSONDuplicateKey(position=middle)",
+		"JSONKey(name=a)",
+	} {
+		if _, _, err = Lookup(spec); err == nil {
+			t.Fatalf("%s: expected an error", spec)
+		}
+	}
+}
@@ -21,7 +21,11 @@ func (p JSONRequest) GetName() string {
 	return p.name
 }
 
-func (p JSONRequest) CreateRequest(requestURL, payload string) (*http.Request, error) {
+func (p JSONRequest) NewConfig(options map[string]string) (Config, error) {
+	return nil, checkOptions(options)
+}
+
+func (p JSONRequest) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
 	reqURL, err := url.Parse(requestURL)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,216 @@
+package placeholder
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultJWTClaim  = "sub"
+	defaultJWTCookie = "token"
+	jwtAlgNone       = "none"
+)
+
+// jwtAlgorithms are the HMAC algorithms used to sign the token.
+var jwtAlgorithms = map[string]func() hash.Hash{
+	"HS256": sha256.New,
+	"HS384": sha512.New384,
+	"HS512": sha512.New,
+}
+
+// JWT places the payload into the claim or the header field of the JSON Web
+// Token, which is sent in the Authorization: Bearer header (JWT) or in the
+// cookie (JWTCookie). The token isn't signed (alg: none) by default, or is
+// signed with HMAC and the configured key. WAFs which don't decode tokens
+// don't see the payload, while the back ends often trust the claims.
+type JWT struct {
+	name   string
+	cookie bool
+}
+
+var DefaultJWT = JWT{name: "JWT"}
+var DefaultJWTCookie = JWT{name: "JWTCookie", cookie: true}
+
+var _ Placeholder = (*JWT)(nil)
+
+// JWTConfig is the configuration of the JWT and JWTCookie placeholders.
+type JWTConfig struct {
+	// Alg is the signing algorithm: none, HS256, HS384 or HS512.
+	Alg string
+
+	// Key is the HMAC key. It's set for the HMAC algorithms only.
+	Key string
+
+	// Claim is the name of the claim with the payload. It's empty if the
+	// payload is sent in the header field.
+	Claim string
+
+	// Header is the name of the header field with the payload, e.g. kid or
+	// jku. It's empty if the payload is sent in the claim.
+	Header string
+
+	// Cookie is the name of the cookie with the token. It's used by the
+	// JWTCookie placeholder only.
+	Cookie string
+}
+
+func (p JWT) GetName() string {
+	return p.name
+}
+
+func (p JWT) NewConfig(options map[string]string) (Config, error) {
+	if options == nil {
+		return nil, nil
+	}
+
+	allowed := []string{"alg", "key", "claim", "header"}
+	if p.cookie {
+		allowed = append(allowed, "name")
+	}
+	if err := checkOptions(options, allowed...); err != nil {
+		return nil, err
+	}
+
+	conf := p.defaultConfig()
+
+	if alg, ok := options["alg"]; ok {
+		if _, ok = jwtAlgorithms[alg]; !ok && alg != jwtAlgNone {
+			return nil, fmt.Errorf("unknown alg %q, must be one of: none, HS256, HS384, HS512", alg)
+		}
+		conf.Alg = alg
+	}
+
+	key, hasKey := options["key"]
+	switch {
+	case conf.Alg == jwtAlgNone && hasKey:
+		return nil, fmt.Errorf("key can't be used with the none alg")
+	case conf.Alg != jwtAlgNone && key == "":
+		return nil, fmt.Errorf("%s alg requires key", conf.Alg)
+	}
+	conf.Key = key
+
+	claim, hasClaim := options["claim"]
+	header, hasHeader := options["header"]
+	switch {
+	case hasClaim && hasHeader:
+		return nil, fmt.Errorf("only one of claim and header can be set")
+	case hasClaim:
+		if claim == "" {
+			return nil, fmt.Errorf("empty claim")
+		}
+		conf.Claim = claim
+	case hasHeader:
+		if header == "" || header == "alg" {
+			return nil, fmt.Errorf("invalid header %q", header)
+		}
+		conf.Claim = ""
+		conf.Header = header
+	}
+
+	if name, ok := options["name"]; ok {
+		if name == "" {
+			return nil, fmt.Errorf("empty name")
+		}
+		conf.Cookie = name
+	}
+
+	return conf, nil
+}
+
+func (p JWT) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	reqURL, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, ok := config.(*JWTConfig)
+	if !ok {
+		conf = p.defaultConfig()
+	}
+
+	token, err := newJWT(conf, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.cookie {
+		req.Header.Set("Cookie", conf.Cookie+"="+token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return req, nil
+}
+
+func (p JWT) defaultConfig() *JWTConfig {
+	conf := &JWTConfig{Alg: jwtAlgNone, Claim: defaultJWTClaim}
+	if p.cookie {
+		conf.Cookie = defaultJWTCookie
+	}
+	return conf
+}
+
+// newJWT creates the token with the payload in the claim or the header field.
+// The token of the none alg has the empty signature, e.g. <header>.<claims>.
+func newJWT(conf *JWTConfig, payload string) (string, error) {
+	subject, err := RandomHex(Seed)
+	if err != nil {
+		return "", err
+	}
+
+	header := map[string]interface{}{"alg": conf.Alg, "typ": "JWT"}
+	claims := map[string]interface{}{"sub": subject, "iat": time.Now().Unix()}
+
+	if conf.Header != "" {
+		header[conf.Header] = payload
+	} else {
+		claims[conf.Claim] = payload
+	}
+
+	encodedHeader, err := jwtEncode(header)
+	if err != nil {
+		return "", err
+	}
+	encodedClaims, err := jwtEncode(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodedHeader + "." + encodedClaims
+	if conf.Alg == jwtAlgNone {
+		return signingInput + ".", nil
+	}
+
+	mac := hmac.New(jwtAlgorithms[conf.Alg], []byte(conf.Key))
+	mac.Write([]byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// jwtEncode encodes the JSON object with the unpadded base64url encoding.
+// The payload isn't escaped for HTML, e.g. < is kept as is.
+func jwtEncode(obj map[string]interface{}) (string, error) {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(obj); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes.TrimSuffix(b.Bytes(), []byte("\n"))), nil
+}
@@ -0,0 +1,95 @@
+package placeholder
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJWT(t *testing.T) {
+	payload := `<script>alert("1")</script>`
+
+	tests := []struct {
+		spec   string
+		field  string
+		header bool
+		alg    string
+	}{
+		{"JWT", "sub", false, "none"},
+		{"JWT(claim=role)", "role", false, "none"},
+		{"JWT(header=kid)", "kid", true, "none"},
+		{"JWT(alg=HS256,key=secret,header=jku)", "jku", true, "HS256"},
+		{"JWTCookie(alg=HS256,key=secret,name=session)", "sub", false, "HS256"},
+	}
+
+	for _, tt := range tests {
+		req, err := Apply("http://example.com", tt.spec, payload)
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", tt.spec, err)
+		}
+
+		var token string
+		if strings.HasPrefix(tt.spec, "JWTCookie") {
+			cookie, err := req.Cookie("session")
+			if err != nil {
+				t.Fatalf("%s: cookie not found: %v", tt.spec, err)
+			}
+			token = cookie.Value
+		} else {
+			token = strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		}
+
+		parts := strings.Split(token, ".")
+		if len(parts) != 3 {
+			t.Fatalf("%s: got invalid token %q", tt.spec, token)
+		}
+
+		var header, claims map[string]interface{}
+		for i, obj := range []*map[string]interface{}{&header, &claims} {
+			b, err := base64.RawURLEncoding.DecodeString(parts[i])
+			if err != nil {
+				t.Fatalf("%s: got invalid base64url: %v", tt.spec, err)
+			}
+			if err = json.Unmarshal(b, obj); err != nil {
+				t.Fatalf("%s: got invalid JSON: %v", tt.spec, err)
+			}
+		}
+
+		if header["alg"] != tt.alg {
+			t.Fatalf("%s: got alg %v, want %s", tt.spec, header["alg"], tt.alg)
+		}
+
+		fields := claims
+		if tt.header {
+			fields = header
+		}
+		if fields[tt.field] != payload {
+			t.Fatalf("%s: got %s %v, want %q", tt.spec, tt.field, fields[tt.field], payload)
+		}
+
+		if tt.alg == "none" {
+			if parts[2] != "" {
+				t.Fatalf("%s: got signature %q, want empty", tt.spec, parts[2])
+			}
+			continue
+		}
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+		if parts[2] != base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) {
+			t.Fatalf("%s: got invalid signature", tt.spec)
+		}
+	}
+
+	for _, spec := range []string{
+		"JWT(alg=RS256)", "JWT(alg=HS256)", "JWT(key=secret)", "JWT(claim=a,header=kid)",
+		"JWT(header=alg)", "JWT(name=a)", "JWTCookie(name=)",
+	} {
+		if _, err := Apply("http://example.com", spec, payload); err == nil {
+			t.Fatalf("%s: invalid options are accepted", spec)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package placeholder
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+const (
+	defaultOverrideMethod       = "PUT"
+	defaultMethodOverrideHeader = "X-HTTP-Method-Override"
+	defaultMethodOverrideParam  = "_method"
+)
+
+// MethodOverride sends the payload in the URL-encoded form field of the POST
+// request, which overrides the request method for the back end. The method is
+// overridden by the header, e.g. X-HTTP-Method-Override: PUT, or by the form
+// field, e.g. _method=PUT. WAFs which check only GET and POST requests, or
+// which apply the rules of the overridden method, may skip the payload.
+type MethodOverride struct {
+	name  string
+	param bool
+}
+
+var DefaultMethodOverrideHeader = MethodOverride{name: "MethodOverrideHeader"}
+var DefaultMethodOverrideParam = MethodOverride{name: "MethodOverrideParam", param: true}
+
+var _ Placeholder = (*MethodOverride)(nil)
+
+// MethodOverrideConfig is the configuration of the MethodOverrideHeader and
+// MethodOverrideParam placeholders.
+type MethodOverrideConfig struct {
+	// Method is the overriding method.
+	Method string
+
+	// Name is the name of the header or the form field with the overriding
+	// method, e.g. X-Method-Override.
+	Name string
+}
+
+func (p MethodOverride) GetName() string {
+	return p.name
+}
+
+func (p MethodOverride) NewConfig(options map[string]string) (Config, error) {
+	if options == nil {
+		return nil, nil
+	}
+
+	if err := checkOptions(options, "method", "name"); err != nil {
+		return nil, err
+	}
+
+	conf := p.defaultConfig()
+
+	if method, ok := options["method"]; ok {
+		if err := checkMethod(method); err != nil {
+			return nil, err
+		}
+		conf.Method = method
+	}
+
+	if name, ok := options["name"]; ok {
+		if p.param && name == "" {
+			return nil, fmt.Errorf("empty name")
+		}
+		if !p.param && !httpguts.ValidHeaderFieldName(name) {
+			return nil, fmt.Errorf("invalid header name %q", name)
+		}
+		conf.Name = name
+	}
+
+	return conf, nil
+}
+
+func (p MethodOverride) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	reqURL, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, ok := config.(*MethodOverrideConfig)
+	if !ok {
+		conf = p.defaultConfig()
+	}
+
+	name, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	body := name + "=" + payload
+	if p.param {
+		body = url.QueryEscape(conf.Name) + "=" + url.QueryEscape(conf.Method) + "&" + body
+	}
+
+	req, err := http.NewRequest("POST", reqURL.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	if !p.param {
+		req.Header.Add(conf.Name, conf.Method)
+	}
+
+	return req, nil
+}
+
+func (p MethodOverride) defaultConfig() *MethodOverrideConfig {
+	conf := &MethodOverrideConfig{Method: defaultOverrideMethod, Name: defaultMethodOverrideHeader}
+	if p.param {
+		conf.Name = defaultMethodOverrideParam
+	}
+	return conf
+}
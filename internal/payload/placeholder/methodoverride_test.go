@@ -0,0 +1,73 @@
+package placeholder
+
+import (
+	"io"
+	"regexp"
+	"testing"
+)
+
+func TestNonCRUDMethod(t *testing.T) {
+	for spec, method := range map[string]string{
+		"NonCRUDHeader":                     "CUST",
+		"NonCRUDHeader(method=PROPFIND)":    "PROPFIND",
+		"NonCrudUrlPath(method=get)":        "get",
+		"NonCrudUrlParam(method=PATCH)":     "PATCH",
+		"NonCRUDRequestBody(method=FOOBAR)": "FOOBAR",
+	} {
+		req, err := Apply("http://example.com", spec, "<script>")
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", spec, err)
+		}
+		if req.Method != method {
+			t.Fatalf("%s: got method %s, want %s", spec, req.Method, method)
+		}
+	}
+
+	for _, spec := range []string{"NonCRUDHeader(method=)", "NonCRUDHeader(method=GET /)", "NonCRUDHeader(name=a)"} {
+		if _, err := Apply("http://example.com", spec, "<script>"); err == nil {
+			t.Fatalf("%s: invalid options are accepted", spec)
+		}
+	}
+}
+
+func TestMethodOverride(t *testing.T) {
+	tests := []struct {
+		spec   string
+		header string
+		value  string
+		body   string
+	}{
+		{"MethodOverrideHeader", "X-HTTP-Method-Override", "PUT", `^[a-f0-9]{10}=<script>$`},
+		{"MethodOverrideHeader(name=X-Method-Override,method=DELETE)", "X-Method-Override", "DELETE", `^[a-f0-9]{10}=<script>$`},
+		{"MethodOverrideParam", "", "", `^_method=PUT&[a-f0-9]{10}=<script>$`},
+		{"MethodOverrideParam(name=_HttpMethod,method=PATCH)", "", "", `^_HttpMethod=PATCH&[a-f0-9]{10}=<script>$`},
+	}
+
+	for _, tt := range tests {
+		req, err := Apply("http://example.com", tt.spec, "<script>")
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", tt.spec, err)
+		}
+
+		if req.Method != "POST" {
+			t.Fatalf("%s: got method %s, want POST", tt.spec, req.Method)
+		}
+		if tt.header != "" && req.Header.Get(tt.header) != tt.value {
+			t.Fatalf("%s: got %s: %q, want %q", tt.spec, tt.header, req.Header.Get(tt.header), tt.value)
+		}
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatalf("%s: couldn't read body: %v", tt.spec, err)
+		}
+		if !regexp.MustCompile(tt.body).Match(body) {
+			t.Fatalf("%s: got body %q, want %s", tt.spec, body, tt.body)
+		}
+	}
+
+	for _, spec := range []string{"MethodOverrideHeader(name=a b)", "MethodOverrideParam(name=)", "MethodOverrideParam(method=a b)"} {
+		if _, err := Apply("http://example.com", spec, "<script>"); err == nil {
+			t.Fatalf("%s: invalid options are accepted", spec)
+		}
+	}
+}
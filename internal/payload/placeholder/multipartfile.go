@@ -0,0 +1,260 @@
+package placeholder
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+const (
+	// multipartFileExt is the extension of the uploaded file if the file
+	// name isn't set.
+	multipartFileExt = ".jpg"
+
+	// multipartLongBoundaryLen is the length of the long boundary. RFC 2046
+	// limits the boundary to 70 characters.
+	multipartLongBoundaryLen = 1024
+
+	// multipartBoundaryPadding is the whitespace around the boundary
+	// parameter in the Content-Type header.
+	multipartBoundaryPadding = " \t "
+)
+
+// multipartFilePosition is the position of the payload in the file part.
+type multipartFilePosition int
+
+const (
+	multipartFilename multipartFilePosition = iota
+	multipartFilenameStar
+	multipartFieldName
+	multipartContentType
+	multipartFileContent
+)
+
+// multipartBoundaryStyle is the style of the boundary in the Content-Type
+// header.
+type multipartBoundaryStyle int
+
+const (
+	multipartQuotedBoundary multipartBoundaryStyle = iota
+	multipartLongBoundary
+	multipartPaddedBoundary
+)
+
+// MultipartFile places the payload into the file part of the multipart form:
+// the file name, the RFC 2231 file name (filename*), the field name, the
+// Content-Type of the part or the file content.
+type MultipartFile struct {
+	name     string
+	position multipartFilePosition
+}
+
+// MultipartBoundary places the payload into the text field of the multipart
+// form with the unusual boundary: quoted, longer than allowed by RFC 2046 or
+// padded with whitespace in the Content-Type header.
+type MultipartBoundary struct {
+	name  string
+	style multipartBoundaryStyle
+}
+
+var DefaultMultipartFilename = MultipartFile{name: "MultipartFilename", position: multipartFilename}
+var DefaultMultipartFilenameStar = MultipartFile{name: "MultipartFilenameStar", position: multipartFilenameStar}
+var DefaultMultipartFieldName = MultipartFile{name: "MultipartFieldName", position: multipartFieldName}
+var DefaultMultipartContentType = MultipartFile{name: "MultipartContentType", position: multipartContentType}
+var DefaultMultipartFileContent = MultipartFile{name: "MultipartFileContent", position: multipartFileContent}
+var DefaultMultipartQuotedBoundary = MultipartBoundary{name: "MultipartQuotedBoundary", style: multipartQuotedBoundary}
+var DefaultMultipartLongBoundary = MultipartBoundary{name: "MultipartLongBoundary", style: multipartLongBoundary}
+var DefaultMultipartPaddedBoundary = MultipartBoundary{name: "MultipartPaddedBoundary", style: multipartPaddedBoundary}
+
+var _ Placeholder = (*MultipartFile)(nil)
+var _ Placeholder = (*MultipartBoundary)(nil)
+
+// MultipartFileConfig is the configuration of the MultipartFileContent
+// placeholder.
+type MultipartFileConfig struct {
+	// Filename is the name of the uploaded file. The Content-Type of the part
+	// is derived from its extension.
+	Filename string
+}
+
+// multipartPart is the part of the multipart form. The headers are written
+// as is, without escaping.
+type multipartPart struct {
+	disposition string
+	contentType string
+	content     string
+}
+
+func (p MultipartFile) GetName() string {
+	return p.name
+}
+
+func (p MultipartFile) NewConfig(options map[string]string) (Config, error) {
+	if p.position != multipartFileContent {
+		return nil, checkOptions(options)
+	}
+
+	if options == nil {
+		return nil, nil
+	}
+
+	if err := checkOptions(options, "filename"); err != nil {
+		return nil, err
+	}
+
+	if options["filename"] == "" {
+		return nil, fmt.Errorf("empty filename")
+	}
+
+	return &MultipartFileConfig{Filename: options["filename"]}, nil
+}
+
+func (p MultipartFile) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	field, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	randomName, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := randomName + multipartFileExt
+	if conf, ok := config.(*MultipartFileConfig); ok {
+		filename = conf.Filename
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(filename))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	// the harmless file content
+	content := randomName
+
+	var disposition string
+
+	switch p.position {
+	case multipartFilename:
+		disposition = fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field, payload)
+		contentType = "text/plain"
+
+	case multipartFilenameStar:
+		disposition = fmt.Sprintf(`form-data; name="%s"; filename*=UTF-8''%s`, field, rfc2231Escape(payload))
+		contentType = "text/plain"
+
+	case multipartFieldName:
+		disposition = fmt.Sprintf(`form-data; name="%s"; filename="%s"`, payload, filename)
+
+	case multipartContentType:
+		disposition = fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field, filename)
+		contentType = payload
+
+	case multipartFileContent:
+		disposition = fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field, filename)
+		content = payload
+	}
+
+	boundary, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	part := multipartPart{
+		disposition: disposition,
+		contentType: contentType,
+		content:     content,
+	}
+
+	return newMultipartRequest(requestURL, boundary, "boundary="+boundary, part)
+}
+
+func (p MultipartBoundary) GetName() string {
+	return p.name
+}
+
+func (p MultipartBoundary) NewConfig(options map[string]string) (Config, error) {
+	return nil, checkOptions(options)
+}
+
+func (p MultipartBoundary) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	field, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	boundary, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	var param string
+
+	switch p.style {
+	case multipartQuotedBoundary:
+		param = `boundary="` + boundary + `"`
+
+	case multipartLongBoundary:
+		boundary = strings.Repeat(boundary, multipartLongBoundaryLen/len(boundary)+1)[:multipartLongBoundaryLen]
+		param = "boundary=" + boundary
+
+	case multipartPaddedBoundary:
+		param = multipartBoundaryPadding + "boundary=" + boundary + multipartBoundaryPadding
+	}
+
+	part := multipartPart{
+		disposition: fmt.Sprintf(`form-data; name="%s"`, field),
+		content:     payload,
+	}
+
+	return newMultipartRequest(requestURL, boundary, param, part)
+}
+
+// newMultipartRequest creates the POST request with the multipart form. The
+// param is the boundary parameter of the Content-Type header.
+func newMultipartRequest(requestURL, boundary, param string, parts ...multipartPart) (*http.Request, error) {
+	reqURL, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	for _, part := range parts {
+		b.WriteString("--" + boundary + "\r\n")
+		b.WriteString("Content-Disposition: " + part.disposition + "\r\n")
+		if part.contentType != "" {
+			b.WriteString("Content-Type: " + part.contentType + "\r\n")
+		}
+		b.WriteString("\r\n" + part.content + "\r\n")
+	}
+	b.WriteString("--" + boundary + "--\r\n")
+
+	req, err := http.NewRequest("POST", reqURL.String(), strings.NewReader(b.String()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "multipart/form-data; "+param)
+
+	return req, nil
+}
+
+// rfc2231Escape percent-encodes the value of the extended parameter, e.g.
+// <script> is encoded as %3Cscript%3E. Only the attribute characters of
+// RFC 5987 are left as is.
+func rfc2231Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+			strings.IndexByte("!#$&+-.^_`|~", c) != -1 {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package placeholder
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+func TestMultipartFile(t *testing.T) {
+	payload := "<script>alert('1');</script>"
+
+	tests := []struct {
+		placeholder string
+		field       string
+		filename    string
+		contentType string
+		content     string
+	}{
+		{placeholder: "MultipartFilename", filename: payload, contentType: "text/plain"},
+		{placeholder: "MultipartFilenameStar", filename: payload, contentType: "text/plain"},
+		{placeholder: "MultipartFieldName", field: payload, contentType: "image/jpeg"},
+		{placeholder: "MultipartContentType", contentType: payload},
+		{placeholder: "MultipartFileContent", contentType: "image/jpeg", content: payload},
+		{placeholder: "MultipartFileContent(filename=report.pdf)", filename: "report.pdf", contentType: "application/pdf", content: payload},
+		{placeholder: "MultipartQuotedBoundary", content: payload},
+		{placeholder: "MultipartLongBoundary", content: payload},
+		{placeholder: "MultipartPaddedBoundary", content: payload},
+	}
+
+	for _, test := range tests {
+		req, err := Apply("http://example.com", test.placeholder, payload)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+
+		mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if err != nil || mediaType != "multipart/form-data" {
+			t.Fatalf("%s: got Content-Type %q (%v)", test.placeholder, req.Header.Get("Content-Type"), err)
+		}
+
+		part, err := multipart.NewReader(req.Body, params["boundary"]).NextPart()
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", test.placeholder, err)
+		}
+
+		if test.field != "" && part.FormName() != test.field {
+			t.Fatalf("%s: got field name %q", test.placeholder, part.FormName())
+		}
+		// Part.FileName strips the directory of the file name
+		_, disposition, err := mime.ParseMediaType(part.Header.Get("Content-Disposition"))
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", test.placeholder, err)
+		}
+		if test.filename != "" && disposition["filename"] != test.filename {
+			t.Fatalf("%s: got file name %q", test.placeholder, disposition["filename"])
+		}
+		if part.Header.Get("Content-Type") != test.contentType {
+			t.Fatalf("%s: got part Content-Type %q", test.placeholder, part.Header.Get("Content-Type"))
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", test.placeholder, err)
+		}
+		if test.content != "" && string(content) != test.content {
+			t.Fatalf("%s: got content %q", test.placeholder, content)
+		}
+	}
+
+	req, err := Apply("http://example.com", "MultipartLongBoundary", payload)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if _, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); len(params["boundary"]) != multipartLongBoundaryLen {
+		t.Fatalf("got boundary of %d characters", len(params["boundary"]))
+	}
+
+	req, err = Apply("http://example.com", "MultipartFilenameStar", payload)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if !strings.Contains(string(body), "filename*=UTF-8''%3Cscript%3Ealert%28%271%27%29%3B%3C%2Fscript%3E") {
+		t.Fatalf("got body %s", body)
+	}
+
+	for _, spec := range []string{"MultipartFilename(filename=a.txt)", "MultipartFileContent(filename=)"} {
+		if _, _, err = Lookup(spec); err == nil {
+			t.Fatalf("%s: expected an error", spec)
+		}
+	}
+}
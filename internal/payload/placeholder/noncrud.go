@@ -1,9 +1,12 @@
 package placeholder
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
+
+	"golang.org/x/net/http/httpguts"
 )
 
 const nonCRUDMethod = "CUST"
@@ -34,11 +37,22 @@ var _ Placeholder = (*NonCrudUrlParam)(nil)
 var _ Placeholder = (*NonCRUDHeader)(nil)
 var _ Placeholder = (*NonCRUDRequestBody)(nil)
 
+// NonCRUDConfig is the configuration of the non-CRUD placeholders.
+type NonCRUDConfig struct {
+	// Method is the request method, e.g. PROPFIND or get. If it's empty, the
+	// CUST method is used.
+	Method string
+}
+
 func (p NonCrudUrlPath) GetName() string {
 	return p.name
 }
 
-func (p NonCrudUrlPath) CreateRequest(requestURL, payload string) (*http.Request, error) {
+func (p NonCrudUrlPath) NewConfig(options map[string]string) (Config, error) {
+	return newNonCRUDConfig(options)
+}
+
+func (p NonCrudUrlPath) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
 	reqURL, err := url.Parse(requestURL)
 	if err != nil {
 		return nil, err
@@ -53,7 +67,7 @@ func (p NonCrudUrlPath) CreateRequest(requestURL, payload string) (*http.Request
 	}
 	urlWithPayload += "/" + payload
 
-	req, err := http.NewRequest(nonCRUDMethod, urlWithPayload, nil)
+	req, err := http.NewRequest(nonCRUDRequestMethod(config), urlWithPayload, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +79,11 @@ func (p NonCrudUrlParam) GetName() string {
 	return p.name
 }
 
-func (p NonCrudUrlParam) CreateRequest(requestURL, payload string) (*http.Request, error) {
+func (p NonCrudUrlParam) NewConfig(options map[string]string) (Config, error) {
+	return newNonCRUDConfig(options)
+}
+
+func (p NonCrudUrlParam) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
 	param, err := RandomHex(Seed)
 	if err != nil {
 		return nil, err
@@ -94,7 +112,7 @@ func (p NonCrudUrlParam) CreateRequest(requestURL, payload string) (*http.Reques
 	}
 	urlWithPayload += param + "=" + payload
 
-	req, err := http.NewRequest(nonCRUDMethod, urlWithPayload, nil)
+	req, err := http.NewRequest(nonCRUDRequestMethod(config), urlWithPayload, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +123,11 @@ func (p NonCRUDHeader) GetName() string {
 	return p.name
 }
 
-func (p NonCRUDHeader) CreateRequest(requestURL, payload string) (*http.Request, error) {
+func (p NonCRUDHeader) NewConfig(options map[string]string) (Config, error) {
+	return newNonCRUDConfig(options)
+}
+
+func (p NonCRUDHeader) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
 	reqURL, err := url.Parse(requestURL)
 	if err != nil {
 		return nil, err
@@ -117,7 +139,7 @@ func (p NonCRUDHeader) CreateRequest(requestURL, payload string) (*http.Request,
 	}
 
 	randomHeader := "X-" + randomName
-	req, err := http.NewRequest(nonCRUDMethod, reqURL.String(), nil)
+	req, err := http.NewRequest(nonCRUDRequestMethod(config), reqURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -129,16 +151,55 @@ func (p NonCRUDRequestBody) GetName() string {
 	return p.name
 }
 
-func (p NonCRUDRequestBody) CreateRequest(requestURL, payload string) (*http.Request, error) {
+func (p NonCRUDRequestBody) NewConfig(options map[string]string) (Config, error) {
+	return newNonCRUDConfig(options)
+}
+
+func (p NonCRUDRequestBody) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
 	reqURL, err := url.Parse(requestURL)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(nonCRUDMethod, reqURL.String(), strings.NewReader(payload))
+	req, err := http.NewRequest(nonCRUDRequestMethod(config), reqURL.String(), strings.NewReader(payload))
 	if err != nil {
 		return nil, err
 	}
 
 	return req, nil
 }
+
+// newNonCRUDConfig creates the configuration of the non-CRUD placeholder with
+// the only method option.
+func newNonCRUDConfig(options map[string]string) (Config, error) {
+	if options == nil {
+		return nil, nil
+	}
+
+	if err := checkOptions(options, "method"); err != nil {
+		return nil, err
+	}
+
+	if err := checkMethod(options["method"]); err != nil {
+		return nil, err
+	}
+
+	return &NonCRUDConfig{Method: options["method"]}, nil
+}
+
+// nonCRUDRequestMethod returns the request method set in the config.
+func nonCRUDRequestMethod(config Config) string {
+	if conf, ok := config.(*NonCRUDConfig); ok {
+		return conf.Method
+	}
+	return nonCRUDMethod
+}
+
+// checkMethod returns an error if the request method isn't a valid token.
+// The method is case-sensitive and may be any token, e.g. get or FOO.
+func checkMethod(method string) error {
+	if !httpguts.ValidHeaderFieldName(method) {
+		return fmt.Errorf("invalid method %q", method)
+	}
+	return nil
+}
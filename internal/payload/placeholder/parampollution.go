@@ -0,0 +1,192 @@
+package placeholder
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultParamPollutionParts = 2
+	maxParamPollutionParts     = 100
+)
+
+// paramLocation is where the polluted parameters are sent.
+type paramLocation int
+
+const (
+	paramInQuery paramLocation = iota
+	paramInBody
+	// paramInQueryAndBody sends the first part in the URL query and the
+	// other parts in the URL-encoded body.
+	paramInQueryAndBody
+)
+
+// paramNotation is how the names of the polluted parameters are written.
+type paramNotation int
+
+const (
+	// paramRepeated repeats the name, e.g. a=part1&a=part2.
+	paramRepeated paramNotation = iota
+	// paramArray uses the array notation, e.g. a[]=part1&a[]=part2.
+	paramArray
+	// paramIndexed uses the indexed array notation, e.g.
+	// a[0]=part1&a[1]=part2.
+	paramIndexed
+)
+
+// ParamPollution splits the payload into parts of about the same length and
+// sends them in several parameters with the same name (HTTP parameter
+// pollution). Back end frameworks join such parameters differently, e.g.
+// take the first or the last value, or concatenate them with a comma, while
+// WAFs often check each value separately.
+type ParamPollution struct {
+	name      string
+	location  paramLocation
+	notation  paramNotation
+	separator string
+}
+
+var DefaultURLParamSplit = ParamPollution{name: "URLParamSplit", location: paramInQuery, notation: paramRepeated, separator: "&"}
+var DefaultURLParamArray = ParamPollution{name: "URLParamArray", location: paramInQuery, notation: paramArray, separator: "&"}
+var DefaultURLParamIndexed = ParamPollution{name: "URLParamIndexed", location: paramInQuery, notation: paramIndexed, separator: "&"}
+var DefaultURLParamSemicolon = ParamPollution{name: "URLParamSemicolon", location: paramInQuery, notation: paramRepeated, separator: ";"}
+var DefaultHTMLFormSplit = ParamPollution{name: "HTMLFormSplit", location: paramInBody, notation: paramRepeated, separator: "&"}
+var DefaultHTMLFormArray = ParamPollution{name: "HTMLFormArray", location: paramInBody, notation: paramArray, separator: "&"}
+var DefaultHTMLFormIndexed = ParamPollution{name: "HTMLFormIndexed", location: paramInBody, notation: paramIndexed, separator: "&"}
+var DefaultHTMLFormSemicolon = ParamPollution{name: "HTMLFormSemicolon", location: paramInBody, notation: paramRepeated, separator: ";"}
+var DefaultQueryBodySplit = ParamPollution{name: "QueryBodySplit", location: paramInQueryAndBody, notation: paramRepeated, separator: "&"}
+
+var _ Placeholder = (*ParamPollution)(nil)
+
+// ParamPollutionConfig is the configuration of the parameter pollution
+// placeholders.
+type ParamPollutionConfig struct {
+	// Name is the name of the parameters. If it's empty, the random name is
+	// used.
+	Name string
+
+	// Parts is the number of parameters the payload is split into.
+	Parts int
+}
+
+func (p ParamPollution) GetName() string {
+	return p.name
+}
+
+func (p ParamPollution) NewConfig(options map[string]string) (Config, error) {
+	if options == nil {
+		return nil, nil
+	}
+
+	if err := checkOptions(options, "name", "parts"); err != nil {
+		return nil, err
+	}
+
+	conf := &ParamPollutionConfig{Parts: defaultParamPollutionParts}
+
+	if name, ok := options["name"]; ok {
+		if name == "" {
+			return nil, fmt.Errorf("empty name")
+		}
+		conf.Name = name
+	}
+
+	if value, ok := options["parts"]; ok {
+		parts, err := strconv.Atoi(value)
+		if err != nil || parts < 2 || parts > maxParamPollutionParts {
+			return nil, fmt.Errorf("invalid parts %q, must be from 2 to %d", value, maxParamPollutionParts)
+		}
+		conf.Parts = parts
+	}
+
+	return conf, nil
+}
+
+func (p ParamPollution) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	var name string
+	parts := defaultParamPollutionParts
+
+	if conf, ok := config.(*ParamPollutionConfig); ok {
+		name = url.QueryEscape(conf.Name)
+		parts = conf.Parts
+	}
+
+	if name == "" {
+		var err error
+		name, err = RandomHex(Seed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	params := make([]string, 0, parts)
+	for i, part := range SplitPayload(payload, parts) {
+		switch p.notation {
+		case paramArray:
+			params = append(params, name+"[]="+part)
+		case paramIndexed:
+			params = append(params, name+"["+strconv.Itoa(i)+"]="+part)
+		default:
+			params = append(params, name+"="+part)
+		}
+	}
+
+	switch p.location {
+	case paramInBody:
+		return newFormRequest(requestURL, strings.Join(params, p.separator))
+
+	case paramInQueryAndBody:
+		reqURL, err := appendQuery(requestURL, params[0])
+		if err != nil {
+			return nil, err
+		}
+		return newFormRequest(reqURL, strings.Join(params[1:], p.separator))
+
+	default:
+		reqURL, err := appendQuery(requestURL, strings.Join(params, p.separator))
+		if err != nil {
+			return nil, err
+		}
+		return http.NewRequest("GET", reqURL, nil)
+	}
+}
+
+// newFormRequest creates the POST request with the URL-encoded form body.
+func newFormRequest(requestURL, body string) (*http.Request, error) {
+	req, err := http.NewRequest("POST", requestURL, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	return req, nil
+}
+
+// SplitPayload splits the payload into n parts of about the same length.
+// UTF-8 characters are not split, because string fields must contain a valid
+// UTF-8 text.
+func SplitPayload(payload string, n int) []string {
+	runes := []rune(payload)
+	if len(runes) < n {
+		n = len(runes)
+	}
+	if n <= 1 {
+		return []string{payload}
+	}
+
+	parts := make([]string, 0, n)
+	size := (len(runes) + n - 1) / n
+
+	for start := 0; start < len(runes); start += size {
+		end := start + size
+		if end > len(runes) {
+			end = len(runes)
+		}
+		parts = append(parts, string(runes[start:end]))
+	}
+
+	return parts
+}
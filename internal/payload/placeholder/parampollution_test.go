@@ -0,0 +1,56 @@
+package placeholder
+
+import (
+	"io"
+	"testing"
+)
+
+func TestParamPollution(t *testing.T) {
+	tests := []struct {
+		spec  string
+		query string
+		body  string
+	}{
+		{"URLParamSplit(name=a)", "a=union &a=select", ""},
+		{"URLParamArray(name=a)", "a[]=union &a[]=select", ""},
+		{"URLParamIndexed(name=a)", "a[0]=union &a[1]=select", ""},
+		{"URLParamSemicolon(name=a)", "a=union ;a=select", ""},
+		{"URLParamSplit(name=a,parts=3)", "a=unio&a=n se&a=lect", ""},
+		{"HTMLFormSplit(name=a)", "", "a=union &a=select"},
+		{"HTMLFormArray(name=a)", "", "a[]=union &a[]=select"},
+		{"HTMLFormIndexed(name=a)", "", "a[0]=union &a[1]=select"},
+		{"HTMLFormSemicolon(name=a)", "", "a=union ;a=select"},
+		{"QueryBodySplit(name=a)", "a=union ", "a=select"},
+	}
+
+	for _, tt := range tests {
+		req, err := Apply("http://example.com/path", tt.spec, "union select")
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", tt.spec, err)
+		}
+
+		if req.URL.RawQuery != tt.query {
+			t.Fatalf("%s: got query %q, want %q", tt.spec, req.URL.RawQuery, tt.query)
+		}
+
+		var body []byte
+		if req.Body != nil {
+			body, err = io.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("%s: couldn't read body: %v", tt.spec, err)
+			}
+			if req.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+				t.Fatalf("%s: got Content-Type %q", tt.spec, req.Header.Get("Content-Type"))
+			}
+		}
+		if string(body) != tt.body {
+			t.Fatalf("%s: got body %q, want %q", tt.spec, body, tt.body)
+		}
+	}
+
+	for _, spec := range []string{"URLParamSplit(parts=1)", "URLParamSplit(parts=x)", "URLParamSplit(name=)", "URLParamSplit(index=1)"} {
+		if _, err := Apply("http://example.com", spec, "union select"); err == nil {
+			t.Fatalf("%s: invalid options are accepted", spec)
+		}
+	}
+}
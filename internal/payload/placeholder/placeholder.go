@@ -1,14 +1,39 @@
 package placeholder
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/status"
 )
 
 const Seed = 5
 
 type Placeholder interface {
 	GetName() string
-	CreateRequest(url, data string) (*http.Request, error)
+
+	// NewConfig creates the configuration of the placeholder from the
+	// options set in the test case, e.g. {name: User-Agent} for the Header
+	// placeholder. The options are nil if they aren't set.
+	NewConfig(options map[string]string) (Config, error)
+
+	// CreateRequest creates the request with the payload. The config is
+	// created by NewConfig.
+	CreateRequest(url, data string, config Config) (*http.Request, error)
+}
+
+// Config is the configuration of the placeholder. The placeholder uses the
+// default behaviour if the config is nil.
+type Config interface{}
+
+// ResponseDecoder is implemented by placeholders which send the payload using
+// RPC protocols carried over HTTP, e.g. gRPC-Web. DecodeResponse returns
+// the RPC status of the response or nil if the response doesn't contain it
+// (e.g. a block page was returned instead).
+type ResponseDecoder interface {
+	DecodeResponse(statusCode int, header http.Header, body []byte) (*status.Status, error)
 }
 
 var Placeholders map[string]Placeholder
@@ -16,27 +41,166 @@ var Placeholders map[string]Placeholder
 func init() {
 	Placeholders = make(map[string]Placeholder)
 	Placeholders[DefaultGRPC.GetName()] = DefaultGRPC
+	Placeholders[DefaultGRPCMetadata.GetName()] = DefaultGRPCMetadata
+	Placeholders[DefaultGRPCBinaryMetadata.GetName()] = DefaultGRPCBinaryMetadata
+	Placeholders[DefaultGRPCClientStream.GetName()] = DefaultGRPCClientStream
+	Placeholders[DefaultGRPCBidiStream.GetName()] = DefaultGRPCBidiStream
+	Placeholders[DefaultGRPCWeb.GetName()] = DefaultGRPCWeb
+	Placeholders[DefaultGRPCWebText.GetName()] = DefaultGRPCWebText
+	Placeholders[DefaultConnect.GetName()] = DefaultConnect
 	Placeholders[DefaultHeader.GetName()] = DefaultHeader
+	Placeholders[DefaultCookie.GetName()] = DefaultCookie
+	Placeholders[DefaultCookieHeader.GetName()] = DefaultCookieHeader
+	Placeholders[DefaultCookiePollution.GetName()] = DefaultCookiePollution
+	Placeholders[DefaultJWT.GetName()] = DefaultJWT
+	Placeholders[DefaultJWTCookie.GetName()] = DefaultJWTCookie
 	Placeholders[DefaultHTMLForm.GetName()] = DefaultHTMLForm
 	Placeholders[DefaultHTMLMultipartForm.GetName()] = DefaultHTMLMultipartForm
+	Placeholders[DefaultMultipartFilename.GetName()] = DefaultMultipartFilename
+	Placeholders[DefaultMultipartFilenameStar.GetName()] = DefaultMultipartFilenameStar
+	Placeholders[DefaultMultipartFieldName.GetName()] = DefaultMultipartFieldName
+	Placeholders[DefaultMultipartContentType.GetName()] = DefaultMultipartContentType
+	Placeholders[DefaultMultipartFileContent.GetName()] = DefaultMultipartFileContent
+	Placeholders[DefaultMultipartQuotedBoundary.GetName()] = DefaultMultipartQuotedBoundary
+	Placeholders[DefaultMultipartLongBoundary.GetName()] = DefaultMultipartLongBoundary
+	Placeholders[DefaultMultipartPaddedBoundary.GetName()] = DefaultMultipartPaddedBoundary
 	Placeholders[DefaultJSONBody.GetName()] = DefaultJSONBody
+	Placeholders[DefaultJSONFormURLEncoded.GetName()] = DefaultJSONFormURLEncoded
+	Placeholders[DefaultJSONTextPlain.GetName()] = DefaultJSONTextPlain
 	Placeholders[DefaultJSONRequest.GetName()] = DefaultJSONRequest
+	Placeholders[DefaultJSONNested.GetName()] = DefaultJSONNested
+	Placeholders[DefaultJSONArray.GetName()] = DefaultJSONArray
+	Placeholders[DefaultJSONKey.GetName()] = DefaultJSONKey
+	Placeholders[DefaultJSONNumericString.GetName()] = DefaultJSONNumericString
+	Placeholders[DefaultJSONDuplicateKey.GetName()] = DefaultJSONDuplicateKey
+	Placeholders[DefaultJSONUnicodeKey.GetName()] = DefaultJSONUnicodeKey
+	Placeholders[DefaultMessagePackBody.GetName()] = DefaultMessagePackBody
+	Placeholders[DefaultCBORBody.GetName()] = DefaultCBORBody
+	Placeholders[DefaultProtobufBody.GetName()] = DefaultProtobufBody
+	Placeholders[DefaultYAMLBody.GetName()] = DefaultYAMLBody
 	Placeholders[DefaultRequestBody.GetName()] = DefaultRequestBody
 	Placeholders[DefaultSOAPBody.GetName()] = DefaultSOAPBody
 	Placeholders[DefaultURLParam.GetName()] = DefaultURLParam
+	Placeholders[DefaultURLParamSplit.GetName()] = DefaultURLParamSplit
+	Placeholders[DefaultURLParamArray.GetName()] = DefaultURLParamArray
+	Placeholders[DefaultURLParamIndexed.GetName()] = DefaultURLParamIndexed
+	Placeholders[DefaultURLParamSemicolon.GetName()] = DefaultURLParamSemicolon
+	Placeholders[DefaultHTMLFormSplit.GetName()] = DefaultHTMLFormSplit
+	Placeholders[DefaultHTMLFormArray.GetName()] = DefaultHTMLFormArray
+	Placeholders[DefaultHTMLFormIndexed.GetName()] = DefaultHTMLFormIndexed
+	Placeholders[DefaultHTMLFormSemicolon.GetName()] = DefaultHTMLFormSemicolon
+	Placeholders[DefaultQueryBodySplit.GetName()] = DefaultQueryBodySplit
 	Placeholders[DefaultURLPath.GetName()] = DefaultURLPath
 	Placeholders[DefaultXMLBody.GetName()] = DefaultXMLBody
+	Placeholders[DefaultXMLAttribute.GetName()] = DefaultXMLAttribute
+	Placeholders[DefaultXMLCDATA.GetName()] = DefaultXMLCDATA
+	Placeholders[DefaultXMLProcessingInstruction.GetName()] = DefaultXMLProcessingInstruction
+	Placeholders[DefaultXMLRPC.GetName()] = DefaultXMLRPC
+	Placeholders[DefaultSOAP12Body.GetName()] = DefaultSOAP12Body
+	Placeholders[DefaultXMLDTD.GetName()] = DefaultXMLDTD
 	Placeholders[DefaultNonCrudUrlPath.GetName()] = DefaultNonCrudUrlPath
 	Placeholders[DefaultNonCrudUrlParam.GetName()] = DefaultNonCrudUrlParam
 	Placeholders[DefaultNonCRUDHeader.GetName()] = DefaultNonCRUDHeader
 	Placeholders[DefaultNonCRUDRequestBody.GetName()] = DefaultNonCRUDRequestBody
+	Placeholders[DefaultMethodOverrideHeader.GetName()] = DefaultMethodOverrideHeader
+	Placeholders[DefaultMethodOverrideParam.GetName()] = DefaultMethodOverrideParam
 }
 
+// Apply creates the request with the payload in the placeholder. The
+// placeholder is set by the spec, e.g. Header or Header(name=User-Agent).
 func Apply(host, placeholder, data string) (*http.Request, error) {
-	req, err := Placeholders[placeholder].CreateRequest(host, data)
+	ph, config, err := Lookup(placeholder)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := ph.CreateRequest(host, data, config)
 	if err != nil {
 		return nil, err
 	}
 
 	return req, nil
 }
+
+// Lookup returns the placeholder and its configuration by the spec.
+func Lookup(spec string) (Placeholder, Config, error) {
+	name, options, err := ParseSpec(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ph, ok := Placeholders[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown placeholder: %s", name)
+	}
+
+	// the body modifiers are applied to the request created by the
+	// placeholder, so they are removed from its options
+	modifier, options, err := newBodyModifier(ph, options)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "placeholder %s", name)
+	}
+
+	config, err := ph.NewConfig(options)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "placeholder %s", name)
+	}
+
+	if modifier != ph {
+		if err = checkBody(ph, config); err != nil {
+			return nil, nil, errors.Wrapf(err, "placeholder %s", name)
+		}
+	}
+
+	return modifier, config, nil
+}
+
+// Get returns the placeholder by the spec or nil if the placeholder is
+// unknown. The options of the spec are ignored.
+func Get(spec string) Placeholder {
+	name, _, err := ParseSpec(spec)
+	if err != nil {
+		return nil
+	}
+	return Placeholders[name]
+}
+
+// newNameConfig creates the configuration of the placeholder with the only
+// name option.
+func newNameConfig(options map[string]string, newConfig func(name string) Config) (Config, error) {
+	if options == nil {
+		return nil, nil
+	}
+
+	if err := checkOptions(options, "name"); err != nil {
+		return nil, err
+	}
+
+	if options["name"] == "" {
+		return nil, errors.New("empty name")
+	}
+
+	return newConfig(options["name"]), nil
+}
+
+// checkOptions returns an error if any of the options isn't allowed.
+func checkOptions(options map[string]string, allowed ...string) error {
+	for option := range options {
+		if !contains(allowed, option) {
+			if len(allowed) == 0 {
+				return fmt.Errorf("options aren't supported, got %q", option)
+			}
+			return fmt.Errorf("unknown option %q, supported: %s", option, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -18,7 +18,11 @@ func (p RequestBody) GetName() string {
 	return p.name
 }
 
-func (p RequestBody) CreateRequest(requestURL, payload string) (*http.Request, error) {
+func (p RequestBody) NewConfig(options map[string]string) (Config, error) {
+	return nil, checkOptions(options)
+}
+
+func (p RequestBody) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
 	reqURL, err := url.Parse(requestURL)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,243 @@
+package placeholder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wallarm/gotestwaf/internal/config"
+)
+
+// PayloadMarker is replaced with the payload in the request template.
+const PayloadMarker = "{{payload}}"
+
+// templateEncodings escape the payload before it is inserted into the
+// request template, so the request stays well-formed.
+var templateEncodings = map[string]func(payload string) string{
+	"":       func(payload string) string { return payload },
+	"none":   func(payload string) string { return payload },
+	"url":    url.QueryEscape,
+	"path":   url.PathEscape,
+	"base64": func(payload string) string { return base64.StdEncoding.EncodeToString([]byte(payload)) },
+	"json":   jsonEscape,
+	"xml":    xmlEscape,
+}
+
+// RequestTemplate is a user-defined placeholder. The payload is inserted into
+// the HTTP request template in place of the PayloadMarker.
+type RequestTemplate struct {
+	name    string
+	method  string
+	path    string
+	headers map[string]string
+	body    string
+
+	// rawHead is the request line and the headers of the raw request
+	rawHead string
+
+	encode func(payload string) string
+}
+
+var _ Placeholder = (*RequestTemplate)(nil)
+
+// NewRequestTemplate creates the placeholder from the request template.
+func NewRequestTemplate(t *config.PlaceholderTemplate) (*RequestTemplate, error) {
+	if t.Name == "" {
+		return nil, errors.New("empty placeholder name")
+	}
+
+	encode, ok := templateEncodings[strings.ToLower(t.Encoding)]
+	if !ok {
+		return nil, fmt.Errorf("placeholder %s: unknown encoding %q, must be one of: %s",
+			t.Name, t.Encoding, strings.Join(requestTemplateEncodings(), ", "))
+	}
+
+	p := &RequestTemplate{
+		name:    t.Name,
+		method:  strings.ToUpper(t.Method),
+		path:    t.Path,
+		headers: t.Headers,
+		body:    t.Body,
+		encode:  encode,
+	}
+
+	var parts []string
+
+	if t.Raw != "" {
+		if p.method != "" || p.path != "" || len(p.headers) != 0 || p.body != "" {
+			return nil, fmt.Errorf("placeholder %s: raw request can't be used with method, path, headers or body", t.Name)
+		}
+
+		p.rawHead, p.body = splitRawRequest(t.Raw)
+
+		// check the raw request with the harmless payload
+		if _, _, _, err := parseRawHead(strings.ReplaceAll(p.rawHead, PayloadMarker, "")); err != nil {
+			return nil, errors.Wrapf(err, "placeholder %s: invalid raw request", t.Name)
+		}
+
+		parts = append(parts, t.Raw)
+	} else {
+		if p.method == "" {
+			p.method = http.MethodGet
+		}
+
+		parts = append(parts, p.path, p.body)
+		for name, value := range p.headers {
+			parts = append(parts, name, value)
+		}
+	}
+
+	if !strings.Contains(strings.Join(parts, "\n"), PayloadMarker) {
+		return nil, fmt.Errorf("placeholder %s: request template doesn't contain %s", t.Name, PayloadMarker)
+	}
+
+	return p, nil
+}
+
+func (p *RequestTemplate) GetName() string {
+	return p.name
+}
+
+func (p *RequestTemplate) NewConfig(options map[string]string) (Config, error) {
+	return nil, checkOptions(options)
+}
+
+func (p *RequestTemplate) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	payload = p.encode(payload)
+	insert := func(s string) string {
+		return strings.ReplaceAll(s, PayloadMarker, payload)
+	}
+
+	method, path, headers, body := p.method, insert(p.path), http.Header{}, insert(p.body)
+
+	if p.rawHead != "" {
+		var err error
+		method, path, headers, err = parseRawHead(insert(p.rawHead))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for name, value := range p.headers {
+			headers.Set(name, insert(value))
+		}
+	}
+
+	reqURL, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if path != "" && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	// the path of the template is relative to the target URL
+	base := strings.TrimRight(reqURL.Scheme+"://"+reqURL.Host+reqURL.Path, "/")
+
+	req, err := http.NewRequest(method, base+path, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range headers {
+		if name == "Host" {
+			req.Host = values[0]
+			continue
+		}
+		req.Header[name] = values
+	}
+
+	return req, nil
+}
+
+// splitRawRequest splits the raw HTTP request into the head (the request line
+// and the headers) and the body separated by the empty line.
+func splitRawRequest(raw string) (head, body string) {
+	raw = strings.TrimLeft(raw, "\r\n")
+
+	for _, sep := range []string{"\r\n\r\n", "\n\n"} {
+		if i := strings.Index(raw, sep); i != -1 {
+			return raw[:i], raw[i+len(sep):]
+		}
+	}
+
+	return strings.TrimRight(raw, "\r\n"), ""
+}
+
+// parseRawHead parses the request line and the headers of the raw HTTP
+// request. The Content-Length header is dropped, it is set by the HTTP
+// client.
+func parseRawHead(head string) (method, path string, headers http.Header, err error) {
+	req, err := http.ReadRequest(bufio.NewReader(strings.NewReader(head + "\r\n\r\n")))
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	headers = req.Header
+	headers.Del("Content-Length")
+	if req.Host != "" {
+		headers.Set("Host", req.Host)
+	}
+
+	return req.Method, req.RequestURI, headers, nil
+}
+
+// RegisterRequestTemplates creates placeholders from the request templates
+// and adds them to the Placeholders.
+func RegisterRequestTemplates(templates []*config.PlaceholderTemplate) error {
+	for _, t := range templates {
+		p, err := NewRequestTemplate(t)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := Placeholders[p.GetName()]; ok {
+			return fmt.Errorf("placeholder %s is already defined", p.GetName())
+		}
+
+		Placeholders[p.GetName()] = p
+	}
+
+	return nil
+}
+
+// requestTemplateEncodings returns names of the supported encodings of the
+// payload in the request templates.
+func requestTemplateEncodings() []string {
+	var names []string
+	for name := range templateEncodings {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func jsonEscape(payload string) string {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	// encoding of the string can't fail
+	_ = enc.Encode(payload)
+
+	s := strings.TrimSuffix(b.String(), "\n")
+	return s[1 : len(s)-1]
+}
+
+func xmlEscape(payload string) string {
+	var b strings.Builder
+	// writing to strings.Builder can't fail
+	_ = xml.EscapeText(&b, []byte(payload))
+	return b.String()
+}
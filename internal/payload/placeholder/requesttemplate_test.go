@@ -0,0 +1,131 @@
+package placeholder
+
+import (
+	"io"
+	"testing"
+
+	"github.com/wallarm/gotestwaf/internal/config"
+)
+
+func TestRequestTemplate(t *testing.T) {
+	tests := []struct {
+		template *config.PlaceholderTemplate
+		payload  string
+		method   string
+		url      string
+		host     string
+		header   [2]string
+		body     string
+	}{
+		{
+			template: &config.PlaceholderTemplate{
+				Name:     "search",
+				Path:     "/search?q={{payload}}",
+				Encoding: "url",
+			},
+			payload: "<a b>",
+			method:  "GET",
+			url:     "http://example.com/api/search?q=%3Ca+b%3E",
+		},
+		{
+			template: &config.PlaceholderTemplate{
+				Name:     "comment",
+				Method:   "put",
+				Path:     "comments/1",
+				Headers:  map[string]string{"content-type": "application/json", "X-Trace": "t-{{payload}}"},
+				Body:     `{"text": "{{payload}}"}`,
+				Encoding: "json",
+			},
+			payload: `"</script>`,
+			method:  "PUT",
+			url:     "http://example.com/api/comments/1",
+			header:  [2]string{"Content-Type", "application/json"},
+			body:    `{"text": "\"</script>"}`,
+		},
+		{
+			template: &config.PlaceholderTemplate{
+				Name: "raw",
+				Raw: "POST /xml?id=1 HTTP/1.1\n" +
+					"Host: internal.example.com\n" +
+					"Content-Type: text/xml\n" +
+					"Content-Length: 100\n" +
+					"\n" +
+					"<a>{{payload}}</a>",
+				Encoding: "xml",
+			},
+			payload: "<b>&",
+			method:  "POST",
+			url:     "http://example.com/api/xml?id=1",
+			host:    "internal.example.com",
+			header:  [2]string{"Content-Type", "text/xml"},
+			body:    "<a>&lt;b&gt;&amp;</a>",
+		},
+	}
+
+	for _, test := range tests {
+		p, err := NewRequestTemplate(test.template)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+
+		req, err := p.CreateRequest("http://example.com/api/?a=b", test.payload, nil)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+
+		if req.Method != test.method {
+			t.Fatalf("%s: got method %s, want %s", p.GetName(), req.Method, test.method)
+		}
+		if req.URL.String() != test.url {
+			t.Fatalf("%s: got URL %s, want %s", p.GetName(), req.URL, test.url)
+		}
+		if test.host != "" && req.Host != test.host {
+			t.Fatalf("%s: got host %s, want %s", p.GetName(), req.Host, test.host)
+		}
+		if test.header[0] != "" && req.Header.Get(test.header[0]) != test.header[1] {
+			t.Fatalf("%s: got header %s: %s, want %s", p.GetName(), test.header[0], req.Header.Get(test.header[0]), test.header[1])
+		}
+		if req.Header.Get("Content-Length") != "" {
+			t.Fatalf("%s: Content-Length header isn't dropped", p.GetName())
+		}
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+		if string(body) != test.body || req.ContentLength != int64(len(test.body)) {
+			t.Fatalf("%s: got body %q (%d bytes), want %q", p.GetName(), body, req.ContentLength, test.body)
+		}
+	}
+}
+
+func TestRequestTemplateErrors(t *testing.T) {
+	for _, template := range []*config.PlaceholderTemplate{
+		{Path: "/?q={{payload}}"},
+		{Name: "no-marker", Path: "/"},
+		{Name: "encoding", Path: "/?q={{payload}}", Encoding: "rot13"},
+		{Name: "mixed", Path: "/", Raw: "GET /?q={{payload}} HTTP/1.1\n"},
+		{Name: "invalid-raw", Raw: "{{payload}}\n"},
+	} {
+		if _, err := NewRequestTemplate(template); err == nil {
+			t.Fatalf("%s: expected an error", template.Name)
+		}
+	}
+}
+
+func TestRegisterRequestTemplates(t *testing.T) {
+	err := RegisterRequestTemplates([]*config.PlaceholderTemplate{{Name: "custom", Path: "/?q={{payload}}"}})
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	defer delete(Placeholders, "custom")
+
+	if _, err = Apply("http://example.com", "custom", "test"); err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	err = RegisterRequestTemplates([]*config.PlaceholderTemplate{{Name: DefaultURLParam.GetName(), Path: "/?q={{payload}}"}})
+	if err == nil {
+		t.Fatalf("expected an error for the built-in placeholder name")
+	}
+}
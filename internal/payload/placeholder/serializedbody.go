@@ -0,0 +1,145 @@
+package placeholder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"net/url"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v2"
+
+	pb "github.com/wallarm/gotestwaf/internal/payload/placeholder/grpc"
+)
+
+// serializationFormat is the format of the request body.
+type serializationFormat int
+
+const (
+	formatMessagePack serializationFormat = iota
+	formatCBOR
+	formatProtobuf
+	formatYAML
+)
+
+// SerializedBody places the payload into the string field of the body
+// serialized with MessagePack, CBOR, protobuf or YAML. The MessagePack, CBOR
+// and YAML bodies are maps with the only field, e.g. {"<name>": "<payload>"}.
+// The protobuf body is the ServiceFooBar.foo request message.
+type SerializedBody struct {
+	name        string
+	format      serializationFormat
+	contentType string
+}
+
+var DefaultMessagePackBody = SerializedBody{name: "MessagePackBody", format: formatMessagePack, contentType: "application/msgpack"}
+var DefaultCBORBody = SerializedBody{name: "CBORBody", format: formatCBOR, contentType: "application/cbor"}
+var DefaultProtobufBody = SerializedBody{name: "ProtobufBody", format: formatProtobuf, contentType: "application/x-protobuf"}
+var DefaultYAMLBody = SerializedBody{name: "YAMLBody", format: formatYAML, contentType: "application/yaml"}
+
+var _ Placeholder = (*SerializedBody)(nil)
+
+// SerializedBodyConfig is the configuration of the MessagePackBody, CBORBody
+// and YAMLBody placeholders.
+type SerializedBodyConfig struct {
+	// Name is the name of the field with the payload. If it's empty, the
+	// random name is used.
+	Name string
+}
+
+func (p SerializedBody) GetName() string {
+	return p.name
+}
+
+func (p SerializedBody) NewConfig(options map[string]string) (Config, error) {
+	if p.format == formatProtobuf {
+		return nil, checkOptions(options)
+	}
+
+	return newNameConfig(options, func(name string) Config {
+		return &SerializedBodyConfig{Name: name}
+	})
+}
+
+func (p SerializedBody) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	reqURL, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var name string
+	if conf, ok := config.(*SerializedBodyConfig); ok {
+		name = conf.Name
+	} else {
+		name, err = RandomHex(Seed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var body []byte
+
+	switch p.format {
+	case formatMessagePack:
+		body = msgpackString(append([]byte{0x81}, msgpackString(nil, name)...), payload)
+
+	case formatCBOR:
+		body = cborString(cborString([]byte{0xa1}, name), payload)
+
+	case formatProtobuf:
+		body, err = proto.Marshal(&pb.Request{Value: payload})
+		if err != nil {
+			return nil, err
+		}
+
+	case formatYAML:
+		body, err = yaml.Marshal(map[string]string{name: payload})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest("POST", reqURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", p.contentType)
+
+	return req, nil
+}
+
+// msgpackString appends the MessagePack str value to the buffer.
+func msgpackString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= 0xff:
+		b = append(b, 0xd9, byte(n))
+	case n <= 0xffff:
+		b = append(b, 0xda)
+		b = binary.BigEndian.AppendUint16(b, uint16(n))
+	default:
+		b = append(b, 0xdb)
+		b = binary.BigEndian.AppendUint32(b, uint32(n))
+	}
+	return append(b, s...)
+}
+
+// cborString appends the CBOR text string (major type 3) to the buffer.
+func cborString(b []byte, s string) []byte {
+	const textString = 3 << 5
+
+	switch n := len(s); {
+	case n < 24:
+		b = append(b, textString|byte(n))
+	case n <= 0xff:
+		b = append(b, textString|24, byte(n))
+	case n <= 0xffff:
+		b = append(b, textString|25)
+		b = binary.BigEndian.AppendUint16(b, uint16(n))
+	default:
+		b = append(b, textString|26)
+		b = binary.BigEndian.AppendUint32(b, uint32(n))
+	}
+	return append(b, s...)
+}
@@ -0,0 +1,101 @@
+package placeholder
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v2"
+
+	pb "github.com/wallarm/gotestwaf/internal/payload/placeholder/grpc"
+)
+
+func TestSerializedBody(t *testing.T) {
+	long := strings.Repeat("a", 300)
+
+	tests := []struct {
+		spec        string
+		payload     string
+		contentType string
+		want        []byte
+	}{
+		{"MessagePackBody(name=q)", "<script>", "application/msgpack", []byte("\x81\xa1q\xa8<script>")},
+		{"MessagePackBody(name=q)", long, "application/msgpack", append([]byte("\x81\xa1q\xda\x01\x2c"), long...)},
+		{"CBORBody(name=q)", "<script>", "application/cbor", []byte("\xa1\x61q\x68<script>")},
+		{"CBORBody(name=q)", long, "application/cbor", append([]byte("\xa1\x61q\x79\x01\x2c"), long...)},
+	}
+
+	for _, tt := range tests {
+		req, err := Apply("http://example.com", tt.spec, tt.payload)
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", tt.spec, err)
+		}
+
+		if got := req.Header.Get("Content-Type"); got != tt.contentType {
+			t.Fatalf("%s: got Content-Type %q, want %q", tt.spec, got, tt.contentType)
+		}
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatalf("%s: couldn't read body: %v", tt.spec, err)
+		}
+		if !bytes.Equal(body, tt.want) {
+			t.Fatalf("%s: got body %q, want %q", tt.spec, body, tt.want)
+		}
+	}
+
+	payload := "<script>alert(\"1\")</script>\n: - #"
+
+	req, err := Apply("http://example.com", "YAMLBody(name=q)", payload)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	var values map[string]string
+	if err = yaml.NewDecoder(req.Body).Decode(&values); err != nil {
+		t.Fatalf("got invalid YAML: %v", err)
+	}
+	if values["q"] != payload {
+		t.Fatalf("YAMLBody: got %v", values)
+	}
+
+	req, err = Apply("http://example.com", "ProtobufBody", payload)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("couldn't read body: %v", err)
+	}
+
+	var msg pb.Request
+	if err = proto.Unmarshal(body, &msg); err != nil {
+		t.Fatalf("got invalid protobuf message: %v", err)
+	}
+	if msg.Value != payload {
+		t.Fatalf("ProtobufBody: got %q, want %q", msg.Value, payload)
+	}
+
+	if _, err = Apply("http://example.com", "ProtobufBody(name=q)", payload); err == nil {
+		t.Fatal("ProtobufBody: the name option is accepted")
+	}
+}
+
+func TestJSONContentTypeConfusion(t *testing.T) {
+	for spec, contentType := range map[string]string{
+		"JSONFormURLEncoded":            "application/x-www-form-urlencoded",
+		"JSONTextPlain(path=user.name)": "text/plain",
+	} {
+		req, err := Apply("http://example.com", spec, "<script>")
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", spec, err)
+		}
+
+		if got := req.Header.Get("Content-Type"); got != contentType {
+			t.Fatalf("%s: got Content-Type %q, want %q", spec, got, contentType)
+		}
+	}
+}
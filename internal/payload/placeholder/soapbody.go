@@ -21,7 +21,11 @@ func (p SOAPBody) GetName() string {
 	return p.name
 }
 
-func (p SOAPBody) CreateRequest(requestURL, payload string) (*http.Request, error) {
+func (p SOAPBody) NewConfig(options map[string]string) (Config, error) {
+	return nil, checkOptions(options)
+}
+
+func (p SOAPBody) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
 	reqURL, err := url.Parse(requestURL)
 	if err != nil {
 		return nil, err
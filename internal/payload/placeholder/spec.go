@@ -0,0 +1,103 @@
+package placeholder
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FormatSpec returns the spec of the placeholder with the options in the
+// canonical form: Name(option1=value1,option2=value2) with the options
+// sorted. The values with special characters are quoted. Without options,
+// the spec is the placeholder name.
+func FormatSpec(name string, options map[string]string) string {
+	if len(options) == 0 {
+		return name
+	}
+
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := options[key]
+		if value == "" || strings.IndexFunc(value, needsQuoting) != -1 {
+			value = strconv.Quote(value)
+		}
+		pairs = append(pairs, key+"="+value)
+	}
+
+	return name + "(" + strings.Join(pairs, ",") + ")"
+}
+
+// ParseSpec parses the spec of the placeholder created by FormatSpec. The
+// options are nil if the spec has no options.
+func ParseSpec(spec string) (name string, options map[string]string, err error) {
+	i := strings.IndexByte(spec, '(')
+	if i == -1 {
+		return spec, nil, nil
+	}
+
+	name = spec[:i]
+	rest := spec[i+1:]
+
+	if !strings.HasSuffix(rest, ")") {
+		return "", nil, fmt.Errorf("invalid placeholder %q: missing closing parenthesis", spec)
+	}
+	rest = rest[:len(rest)-1]
+
+	options = make(map[string]string)
+
+	for rest != "" {
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return "", nil, fmt.Errorf("invalid placeholder %q: expected option=value", spec)
+		}
+
+		key := rest[:eq]
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid placeholder %q: invalid quoted value", spec)
+			}
+			value, _ = strconv.Unquote(quoted)
+			rest = rest[len(quoted):]
+		} else {
+			end := strings.IndexByte(rest, ',')
+			if end == -1 {
+				end = len(rest)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+
+		if _, ok := options[key]; ok {
+			return "", nil, fmt.Errorf("invalid placeholder %q: duplicate option %q", spec, key)
+		}
+		options[key] = value
+
+		if rest != "" {
+			if rest[0] != ',' {
+				return "", nil, fmt.Errorf("invalid placeholder %q: expected comma after the value of %q", spec, key)
+			}
+			rest = rest[1:]
+			if rest == "" {
+				return "", nil, fmt.Errorf("invalid placeholder %q: trailing comma", spec)
+			}
+		}
+	}
+
+	return name, options, nil
+}
+
+func needsQuoting(r rune) bool {
+	return strings.ContainsRune(`,=()"\`, r) || unicode.IsSpace(r) || !unicode.IsPrint(r)
+}
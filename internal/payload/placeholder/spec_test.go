@@ -0,0 +1,112 @@
+package placeholder
+
+import (
+	"io"
+	"testing"
+)
+
+func TestSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]string
+		spec    string
+	}{
+		{"Header", nil, "Header"},
+		{"Header", map[string]string{"name": "User-Agent"}, "Header(name=User-Agent)"},
+		{"JSONBody", map[string]string{"path": "user.name", "b": "x y", "a": ""}, `JSONBody(a="",b="x y",path=user.name)`},
+		{"URLParam", map[string]string{"name": `a,b=(c)"d"`}, `URLParam(name="a,b=(c)\"d\"")`},
+	}
+
+	for _, test := range tests {
+		spec := FormatSpec(test.name, test.options)
+		if spec != test.spec {
+			t.Fatalf("got spec %s, want %s", spec, test.spec)
+		}
+
+		name, options, err := ParseSpec(spec)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+		if name != test.name || len(options) != len(test.options) {
+			t.Fatalf("%s: got name %s and options %v", spec, name, options)
+		}
+		for key, value := range test.options {
+			if options[key] != value {
+				t.Fatalf("%s: got option %s=%q, want %q", spec, key, options[key], value)
+			}
+		}
+	}
+
+	for _, spec := range []string{
+		"Header(name=a",
+		"Header(name)",
+		"Header(=a)",
+		"Header(name=a,)",
+		"Header(name=a,name=b)",
+		`Header(name="a)`,
+		`Header(name="a"b)`,
+	} {
+		if _, _, err := ParseSpec(spec); err == nil {
+			t.Fatalf("%s: expected an error", spec)
+		}
+	}
+}
+
+func TestPlaceholderOptions(t *testing.T) {
+	req, err := Apply("http://example.com", "Header(name=User-Agent)", "test")
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if req.Header.Get("User-Agent") != "test" {
+		t.Fatalf("got headers %v", req.Header)
+	}
+
+	req, err = Apply("http://example.com", "Header(name=Host)", "evil.com")
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if req.Host != "evil.com" {
+		t.Fatalf("got host %s, want evil.com", req.Host)
+	}
+
+	req, err = Apply("http://example.com/?a=b", "URLParam(name=q)", "<test>")
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if req.URL.String() != "http://example.com/?a=b&q=<test>" {
+		t.Fatalf("got URL %s", req.URL)
+	}
+
+	req, err = Apply("http://example.com", "HTMLForm(name=comment)", "test")
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if err = req.ParseForm(); err != nil || req.PostForm.Get("comment") != "test" {
+		t.Fatalf("got form %v (%v)", req.PostForm, err)
+	}
+
+	req, err = Apply("http://example.com", "JSONBody(path=user.name)", `"<a>`)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if string(body) != `{"user":{"name":"\"<a>"}}` {
+		t.Fatalf("got body %s", body)
+	}
+
+	for _, spec := range []string{
+		"Header(value=a)",
+		"Header(name=a b)",
+		"URLParam(name=)",
+		"JSONBody(path=a..b)",
+		"URLPath(name=a)",
+		"Unknown(name=a)",
+	} {
+		if _, _, err = Lookup(spec); err == nil {
+			t.Fatalf("%s: expected an error", spec)
+		}
+	}
+}
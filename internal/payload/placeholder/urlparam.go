@@ -14,20 +14,77 @@ var DefaultURLParam = URLParam{name: "URLParam"}
 
 var _ Placeholder = (*URLParam)(nil)
 
+// URLParamConfig is the configuration of the URLParam placeholder.
+type URLParamConfig struct {
+	// Name is the name of the URL parameter. If it's empty, the random name
+	// is used.
+	Name string
+}
+
 func (p URLParam) GetName() string {
 	return p.name
 }
 
-func (p URLParam) CreateRequest(requestURL, payload string) (*http.Request, error) {
-	param, err := RandomHex(Seed)
+func (p URLParam) NewConfig(options map[string]string) (Config, error) {
+	return newNameConfig(options, func(name string) Config {
+		return &URLParamConfig{Name: name}
+	})
+}
+
+func (p URLParam) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	return p.CreateSplitRequest(requestURL, []string{payload}, config)
+}
+
+// CreateSplitRequest places the payload parts into the repeated URL parameter
+// with the same name, e.g. ?a=part1&a=part2. Some back ends join the values
+// of the repeated parameters, while WAFs often check them separately.
+func (p URLParam) CreateSplitRequest(requestURL string, parts []string, config Config) (*http.Request, error) {
+	var param string
+	if conf, ok := config.(*URLParamConfig); ok {
+		param = url.QueryEscape(conf.Name)
+	}
+
+	return p.createRequest(requestURL, param, parts)
+}
+
+// createRequest places the payload parts into the URL parameter. If the
+// parameter name is empty, the random name is used.
+func (p URLParam) createRequest(requestURL, param string, parts []string) (*http.Request, error) {
+	if param == "" {
+		var err error
+		param, err = RandomHex(Seed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var query strings.Builder
+	for i, part := range parts {
+		if i > 0 {
+			query.WriteString("&")
+		}
+		query.WriteString(param + "=" + part)
+	}
+
+	urlWithPayload, err := appendQuery(requestURL, query.String())
 	if err != nil {
 		return nil, err
 	}
 
-	reqURL, err := url.Parse(requestURL)
+	req, err := http.NewRequest("GET", urlWithPayload, nil)
 	if err != nil {
 		return nil, err
 	}
+	return req, err
+}
+
+// appendQuery appends the raw query to the query of the URL. The query is
+// added as is, without escaping.
+func appendQuery(requestURL, query string) (string, error) {
+	reqURL, err := url.Parse(requestURL)
+	if err != nil {
+		return "", err
+	}
 
 	reqURL.Fragment = ""
 	urlWithPayload := reqURL.String()
@@ -45,11 +102,6 @@ func (p URLParam) CreateRequest(requestURL, payload string) (*http.Request, erro
 	} else {
 		urlWithPayload += "&"
 	}
-	urlWithPayload += param + "=" + payload
 
-	req, err := http.NewRequest("GET", urlWithPayload, nil)
-	if err != nil {
-		return nil, err
-	}
-	return req, err
+	return urlWithPayload + query, nil
 }
@@ -17,7 +17,11 @@ func (p URLPath) GetName() string {
 	return p.name
 }
 
-func (p URLPath) CreateRequest(requestURL, payload string) (*http.Request, error) {
+func (p URLPath) NewConfig(options map[string]string) (Config, error) {
+	return nil, checkOptions(options)
+}
+
+func (p URLPath) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
 	reqURL, err := url.Parse(requestURL)
 	if err != nil {
 		return nil, err
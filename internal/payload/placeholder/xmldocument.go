@@ -0,0 +1,141 @@
+package placeholder
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const xmlDeclaration = `<?xml version="1.0" encoding="UTF-8"?>`
+
+// xmlGeneralEntityRe matches names of the general entities declared in the
+// DTD. The parameter entities (<!ENTITY % name ...>) are referenced in the DTD
+// itself.
+var xmlGeneralEntityRe = regexp.MustCompile(`<!ENTITY\s+([A-Za-z_][\w.-]*)\s`)
+
+// xmlPosition is the position of the payload in the XML document.
+type xmlPosition int
+
+const (
+	xmlAttribute xmlPosition = iota
+	xmlCDATA
+	xmlProcessingInstruction
+	xmlRPC
+	xmlSOAP12
+	xmlDTD
+)
+
+// XMLDocument places the payload into the well-formed XML document: the
+// attribute value, the CDATA section, the processing instruction, the string
+// parameter of the XML-RPC method call, the body of the SOAP 1.2 envelope or
+// the internal DTD.
+type XMLDocument struct {
+	name     string
+	position xmlPosition
+}
+
+var DefaultXMLAttribute = XMLDocument{name: "XMLAttribute", position: xmlAttribute}
+var DefaultXMLCDATA = XMLDocument{name: "XMLCDATA", position: xmlCDATA}
+var DefaultXMLProcessingInstruction = XMLDocument{name: "XMLProcessingInstruction", position: xmlProcessingInstruction}
+var DefaultXMLRPC = XMLDocument{name: "XMLRPC", position: xmlRPC}
+var DefaultSOAP12Body = XMLDocument{name: "SOAP12Body", position: xmlSOAP12}
+var DefaultXMLDTD = XMLDocument{name: "XMLDTD", position: xmlDTD}
+
+var _ Placeholder = (*XMLDocument)(nil)
+
+// XMLRPCConfig is the configuration of the XMLRPC placeholder.
+type XMLRPCConfig struct {
+	// Method is the name of the called method. If it's empty, the random
+	// name is used.
+	Method string
+}
+
+func (p XMLDocument) GetName() string {
+	return p.name
+}
+
+func (p XMLDocument) NewConfig(options map[string]string) (Config, error) {
+	if p.position != xmlRPC {
+		return nil, checkOptions(options)
+	}
+
+	if options == nil {
+		return nil, nil
+	}
+
+	if err := checkOptions(options, "method"); err != nil {
+		return nil, err
+	}
+
+	if options["method"] == "" {
+		return nil, fmt.Errorf("empty method")
+	}
+
+	return &XMLRPCConfig{Method: options["method"]}, nil
+}
+
+func (p XMLDocument) CreateRequest(requestURL, payload string, config Config) (*http.Request, error) {
+	reqURL, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// XML names can't start with a digit
+	name, err := RandomHex(Seed)
+	if err != nil {
+		return nil, err
+	}
+	name = "ab" + name
+
+	contentType := "text/xml"
+	var body string
+
+	switch p.position {
+	case xmlAttribute:
+		body = fmt.Sprintf(`%s<%s><item value="%s"/></%s>`, xmlDeclaration, name, xmlEscape(payload), name)
+
+	case xmlCDATA:
+		// the end of the CDATA section in the payload is split between two
+		// sections
+		cdata := strings.ReplaceAll(payload, "]]>", "]]]]><![CDATA[>")
+		body = fmt.Sprintf(`%s<%s><item><![CDATA[%s]]></item></%s>`, xmlDeclaration, name, cdata, name)
+
+	case xmlProcessingInstruction:
+		pi := strings.ReplaceAll(payload, "?>", "? >")
+		body = fmt.Sprintf(`%s<?%s %s?><%s/>`, xmlDeclaration, name, pi, name)
+
+	case xmlRPC:
+		method := name
+		if conf, ok := config.(*XMLRPCConfig); ok {
+			method = conf.Method
+		}
+
+		body = fmt.Sprintf(`%s<methodCall><methodName>%s</methodName><params><param><value><string>%s</string></value></param></params></methodCall>`,
+			xmlDeclaration, xmlEscape(method), xmlEscape(payload))
+
+	case xmlSOAP12:
+		contentType = "application/soap+xml; charset=utf-8"
+		body = fmt.Sprintf(`%s<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Header/><soap:Body><%s xmlns="http://example.com/"><value>%s</value></%s></soap:Body></soap:Envelope>`,
+			xmlDeclaration, name, xmlEscape(payload), name)
+
+	case xmlDTD:
+		// the general entities declared in the payload are referenced in the
+		// root element
+		var refs strings.Builder
+		for _, m := range xmlGeneralEntityRe.FindAllStringSubmatch(payload, -1) {
+			refs.WriteString("&" + m[1] + ";")
+		}
+
+		body = fmt.Sprintf("%s\n<!DOCTYPE %s [\n%s\n]>\n<%s>%s</%s>", xmlDeclaration, name, payload, name, refs.String(), name)
+	}
+
+	req, err := http.NewRequest("POST", reqURL.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
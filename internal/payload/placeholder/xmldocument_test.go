@@ -0,0 +1,80 @@
+package placeholder
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestXMLDocument(t *testing.T) {
+	payload := `<script>alert("1")</script>]]>?>`
+
+	for _, name := range []string{"XMLAttribute", "XMLCDATA", "XMLProcessingInstruction", "XMLRPC", "XMLRPC(method=system.listMethods)", "SOAP12Body"} {
+		req, err := Apply("http://example.com", name, payload)
+		if err != nil {
+			t.Fatalf("got an error while testing: %v", err)
+		}
+
+		var values []string
+
+		dec := xml.NewDecoder(req.Body)
+		for {
+			tok, err := dec.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s: got invalid XML: %v", name, err)
+			}
+
+			switch tok := tok.(type) {
+			case xml.StartElement:
+				for _, attr := range tok.Attr {
+					values = append(values, attr.Value)
+				}
+			case xml.CharData:
+				values = append(values, string(tok))
+			case xml.ProcInst:
+				values = append(values, string(tok.Inst))
+			}
+		}
+
+		got := strings.Join(values, "")
+		want := payload
+		if name == "XMLProcessingInstruction" {
+			want = strings.ReplaceAll(payload, "?>", "? >")
+		}
+		if !strings.Contains(got, want) {
+			t.Fatalf("%s: payload not found in %q", name, got)
+		}
+		if strings.HasPrefix(name, "XMLRPC(") && !strings.Contains(got, "system.listMethods") {
+			t.Fatalf("%s: method not found in %q", name, got)
+		}
+	}
+
+	req, err := Apply("http://example.com", "SOAP12Body", payload)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if !strings.HasPrefix(req.Header.Get("Content-Type"), "application/soap+xml") {
+		t.Fatalf("got Content-Type %s", req.Header.Get("Content-Type"))
+	}
+
+	xxe := `<!ENTITY % ext SYSTEM "http://example.com/ext.dtd"> %ext; <!ENTITY xxe SYSTEM "file:///etc/passwd">`
+	req, err = Apply("http://example.com", "XMLDTD", xxe)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if !strings.Contains(string(body), " [\n"+xxe+"\n]>\n") || !strings.Contains(string(body), ">&xxe;</") || strings.Contains(string(body), "&ext;") {
+		t.Fatalf("got body %s", body)
+	}
+
+	if _, _, err = Lookup("XMLAttribute(method=a)"); err == nil {
+		t.Fatalf("expected an error for the XMLAttribute options")
+	}
+}
@@ -236,6 +236,50 @@ func printConsoleReportTable(
 	sumTable.SetFooter(footer)
 	sumTable.Render()
 
+	if len(s.DerivedBypasses) != 0 {
+		fmt.Fprintf(&buffer, "\nDerived Bypasses:\n")
+
+		// derived bypasses table
+		derivedTable := tablewriter.NewWriter(&buffer)
+		baseHeader = []string{"Test set", "Test case", "Placeholder", "Encoder", "Mutations", "Requests"}
+		derivedTable.SetHeader(baseHeader)
+
+		for _, b := range s.DerivedBypasses {
+			derivedTable.Append([]string{
+				b.Set,
+				b.Case,
+				b.Placeholder,
+				b.Encoder,
+				strings.Join(b.Mutations, ", "),
+				fmt.Sprintf("%d", b.Requests),
+			})
+		}
+
+		derivedTable.Render()
+	}
+
+	if len(s.InspectionLimits) != 0 {
+		fmt.Fprintf(&buffer, "\nInspection Limits:\n")
+
+		// inspection limits table
+		limitsTable := tablewriter.NewWriter(&buffer)
+		baseHeader = []string{"Placeholder", "Limit", "Test set", "Test case", "Encoder", "Requests"}
+		limitsTable.SetHeader(baseHeader)
+
+		for _, l := range s.InspectionLimits {
+			limitsTable.Append([]string{
+				l.Placeholder,
+				inspectionLimitString(l),
+				l.Set,
+				l.Case,
+				l.Encoder,
+				fmt.Sprintf("%d", l.Requests),
+			})
+		}
+
+		limitsTable.Render()
+	}
+
 	fmt.Println(buffer.String())
 }
 
@@ -256,6 +300,9 @@ func printConsoleReportJson(
 		Score:       s.Score.Average,
 	}
 
+	report.DerivedBypasses = derivedBypasses(s)
+	report.InspectionLimits = inspectionLimits(s)
+
 	if len(s.NegativeTests.SummaryTable) != 0 {
 		report.NegativeTests = &testsInfo{
 			Score:           s.NegativeTests.ResolvedBlockedRequestsPercentage,
@@ -317,3 +364,16 @@ func printConsoleReportJson(
 
 	return nil
 }
+
+// inspectionLimitString returns the inspection limit in the human-readable
+// form, e.g. 8193 bytes.
+func inspectionLimitString(l *db.InspectionLimit) string {
+	switch l.Result {
+	case db.LimitFound:
+		return fmt.Sprintf("%d %s", l.Limit, l.Unit)
+	case db.LimitNotReached:
+		return fmt.Sprintf("> %d %s", l.MaxPadding, l.Unit)
+	default:
+		return l.Result
+	}
+}
@@ -413,6 +413,27 @@ func prepareHTMLFullReport(
 
 	data.ScannedPaths = s.Paths
 
+	for _, b := range s.DerivedBypasses {
+		data.DerivedBypasses = append(data.DerivedBypasses, &report.DerivedBypass{
+			Payload:        truncatePayload(b.Payload),
+			MutatedPayload: truncatePayload(b.MutatedPayload),
+			TestCase:       b.Case,
+			Placeholder:    b.Placeholder,
+			Mutations:      b.Mutations,
+			Requests:       b.Requests,
+		})
+	}
+
+	for _, l := range s.InspectionLimits {
+		data.InspectionLimits = append(data.InspectionLimits, &report.InspectionLimit{
+			Placeholder: l.Placeholder,
+			Limit:       inspectionLimitString(l),
+			Payload:     truncatePayload(l.Payload),
+			TestCase:    l.Case,
+			Requests:    l.Requests,
+		})
+	}
+
 	data.NegativeTests.Bypassed = negBypassed
 	data.NegativeTests.Unresolved = negUnresolved
 	data.NegativeTests.Failed = s.NegativeTests.Failed
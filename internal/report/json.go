@@ -27,6 +27,9 @@ type jsonReport struct {
 	Summary               *summary      `json:"summary,omitempty"`
 	NegativeTestsPayloads *testPayloads `json:"negative_payloads,omitempty"`
 	PositiveTestsPayloads *testPayloads `json:"positive_payloads,omitempty"`
+
+	DerivedBypasses  []*derivedBypassDetails   `json:"derived_bypasses,omitempty"`
+	InspectionLimits []*inspectionLimitDetails `json:"inspection_limits,omitempty"`
 }
 
 type testsInfo struct {
@@ -80,6 +83,80 @@ type payloadDetails struct {
 	Reason []string `json:"reason,omitempty"`
 }
 
+type derivedBypassDetails struct {
+	Payload        string   `json:"payload"`
+	TestSet        string   `json:"test_set"`
+	TestCase       string   `json:"test_case"`
+	Encoder        string   `json:"encoder"`
+	Placeholder    string   `json:"placeholder"`
+	Mutations      []string `json:"mutations"`
+	MutatedEncoder string   `json:"mutated_encoder"`
+	MutatedPayload string   `json:"mutated_payload"`
+	Split          bool     `json:"split,omitempty"`
+	Status         int      `json:"status,omitempty"`
+	Requests       int      `json:"requests"`
+}
+
+// derivedBypasses converts derived bypasses found by the payload mutation
+// to the JSON report format.
+func derivedBypasses(s *db.Statistics) []*derivedBypassDetails {
+	var details []*derivedBypassDetails
+
+	for _, b := range s.DerivedBypasses {
+		details = append(details, &derivedBypassDetails{
+			Payload:        b.Payload,
+			TestSet:        b.Set,
+			TestCase:       b.Case,
+			Encoder:        b.Encoder,
+			Placeholder:    b.Placeholder,
+			Mutations:      b.Mutations,
+			MutatedEncoder: b.MutatedEncoder,
+			MutatedPayload: b.MutatedPayload,
+			Split:          b.Split,
+			Status:         b.ResponseStatusCode,
+			Requests:       b.Requests,
+		})
+	}
+
+	return details
+}
+
+type inspectionLimitDetails struct {
+	Placeholder string `json:"placeholder"`
+	Unit        string `json:"unit"`
+	Result      string `json:"result"`
+	Limit       int    `json:"limit,omitempty"`
+	MaxPadding  int    `json:"max_padding"`
+	Payload     string `json:"payload"`
+	TestSet     string `json:"test_set"`
+	TestCase    string `json:"test_case"`
+	Encoder     string `json:"encoder"`
+	Requests    int    `json:"requests"`
+}
+
+// inspectionLimits converts inspection limits found by the limit probe to
+// the JSON report format.
+func inspectionLimits(s *db.Statistics) []*inspectionLimitDetails {
+	var details []*inspectionLimitDetails
+
+	for _, l := range s.InspectionLimits {
+		details = append(details, &inspectionLimitDetails{
+			Placeholder: l.Placeholder,
+			Unit:        l.Unit,
+			Result:      l.Result,
+			Limit:       l.Limit,
+			MaxPadding:  l.MaxPadding,
+			Payload:     l.Payload,
+			TestSet:     l.Set,
+			TestCase:    l.Case,
+			Encoder:     l.Encoder,
+			Requests:    l.Requests,
+		})
+	}
+
+	return details
+}
+
 // printFullReportToJson prepares and prints a full report in JSON format to the file.
 func printFullReportToJson(
 	s *db.Statistics, reportFile string, reportTime time.Time,
@@ -234,6 +311,9 @@ func printFullReportToJson(
 		report.PositiveTestsPayloads.Failed = append(report.PositiveTestsPayloads.Failed, failedDetail)
 	}
 
+	report.DerivedBypasses = derivedBypasses(s)
+	report.InspectionLimits = inspectionLimits(s)
+
 	jsonBytes, err := json.MarshalIndent(report, "", "    ")
 	if err != nil {
 		return errors.Wrap(err, "couldn't dump report to JSON")
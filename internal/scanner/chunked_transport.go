@@ -0,0 +1,294 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/wallarm/gotestwaf/internal/payload/placeholder"
+)
+
+// chunkedTransport sends requests with the placeholder.ChunkedBody over a new
+// connection, writing the body as is. net/http encodes the chunked body
+// itself and can't send chunk extensions. Other requests are sent by the
+// embedded transport.
+type chunkedTransport struct {
+	*http.Transport
+}
+
+var _ http.RoundTripper = (*chunkedTransport)(nil)
+
+func (t *chunkedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, ok := req.Body.(*placeholder.ChunkedBody)
+	if !ok {
+		return t.Transport.RoundTrip(req)
+	}
+	defer body.Close()
+
+	ctx := req.Context()
+
+	conn, err := t.dial(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// the connection is closed if the request is canceled while the request
+	// is written or the response is read
+	watcher := newConnWatcher(ctx, conn)
+
+	resp, err := t.send(conn, req, body)
+	if err != nil {
+		watcher.stop()
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	resp.Body = &connBody{ReadCloser: resp.Body, conn: conn, watcher: watcher}
+
+	return resp, nil
+}
+
+// send writes the request with the encoded body to the connection and reads
+// the response.
+func (t *chunkedTransport) send(conn net.Conn, req *http.Request, body io.Reader) (*http.Response, error) {
+	if deadline, ok := req.Context().Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	w := bufio.NewWriter(conn)
+	fmt.Fprintf(w, "%s %s HTTP/1.1\r\nHost: %s\r\n", req.Method, req.URL.RequestURI(), host)
+	err := req.Header.WriteSubset(w, map[string]bool{
+		"Host":              true,
+		"Content-Length":    true,
+		"Transfer-Encoding": true,
+		"Connection":        true,
+	})
+	if err == nil && req.Header.Get("User-Agent") == "" {
+		// the same default as in net/http
+		_, err = w.WriteString("User-Agent: Go-http-client/1.1\r\n")
+	}
+	if err == nil {
+		_, err = w.WriteString("Transfer-Encoding: chunked\r\nConnection: close\r\n\r\n")
+	}
+	if err == nil {
+		_, err = io.Copy(w, body)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't write request")
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't read response")
+	}
+
+	return resp, nil
+}
+
+// dial opens the connection to the host of the request. If the proxy is
+// set, the connection is tunneled through it with the CONNECT method, so the
+// proxy doesn't re-encode the chunked body.
+func (t *chunkedTransport) dial(ctx context.Context, req *http.Request) (net.Conn, error) {
+	addr := hostPort(req.URL)
+
+	var proxyURL *url.URL
+	if t.Proxy != nil {
+		var err error
+		proxyURL, err = t.Proxy(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var conn net.Conn
+	var err error
+
+	if proxyURL == nil {
+		conn, err = t.dialContext(ctx, addr)
+	} else {
+		conn, err = t.dialProxy(ctx, proxyURL, addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL.Scheme != "https" {
+		return conn, nil
+	}
+
+	tlsConn, err := t.handshake(ctx, conn, req.URL.Hostname())
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
+
+// dialContext opens the TCP connection with the dialer of the embedded
+// transport.
+func (t *chunkedTransport) dialContext(ctx context.Context, addr string) (net.Conn, error) {
+	if t.DialContext != nil {
+		return t.DialContext(ctx, "tcp", addr)
+	}
+
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", addr)
+}
+
+// dialProxy opens the tunnel to the address through the HTTP proxy.
+func (t *chunkedTransport) dialProxy(ctx context.Context, proxyURL *url.URL, addr string) (net.Conn, error) {
+	if proxyURL.Scheme != "http" && proxyURL.Scheme != "https" {
+		return nil, fmt.Errorf("chunk extensions can't be sent through the %s proxy", proxyURL.Scheme)
+	}
+
+	conn, err := t.dialContext(ctx, hostPort(proxyURL))
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't connect to proxy")
+	}
+
+	if proxyURL.Scheme == "https" {
+		tlsConn, err := t.handshake(ctx, conn, proxyURL.Hostname())
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	connectReq := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	for key, values := range t.ProxyConnectHeader {
+		connectReq.Header[key] = values
+	}
+	if user := proxyURL.User; user != nil {
+		password, _ := user.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		connectReq.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+
+	watcher := newConnWatcher(ctx, conn)
+	defer watcher.stop()
+
+	err = connectReq.Write(conn)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "couldn't write CONNECT request to proxy")
+	}
+
+	// the proxy sends nothing after the response until the request is sent
+	// through the tunnel, so the buffered data isn't lost
+	resp, err := http.ReadResponse(bufio.NewReader(conn), connectReq)
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, errors.Wrap(err, "couldn't read CONNECT response from proxy")
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy refused CONNECT: %s", resp.Status)
+	}
+
+	return conn, nil
+}
+
+// handshake starts the TLS session over the connection.
+func (t *chunkedTransport) handshake(ctx context.Context, conn net.Conn, serverName string) (net.Conn, error) {
+	cfg := &tls.Config{}
+	if t.TLSClientConfig != nil {
+		cfg = t.TLSClientConfig.Clone()
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName = serverName
+	}
+	// chunked transfer encoding is the HTTP/1.1 feature
+	cfg.NextProtos = []string{"http/1.1"}
+
+	tlsConn := tls.Client(conn, cfg)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return nil, errors.Wrap(err, "TLS handshake failed")
+	}
+
+	return tlsConn, nil
+}
+
+// hostPort returns the address of the URL host with the default port of the
+// scheme if the port isn't set.
+func hostPort(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// connWatcher closes the connection when the context is done, which
+// interrupts blocked reads and writes.
+type connWatcher struct {
+	done chan struct{}
+	once sync.Once
+}
+
+func newConnWatcher(ctx context.Context, conn net.Conn) *connWatcher {
+	w := &connWatcher{done: make(chan struct{})}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-w.done:
+		}
+	}()
+
+	return w
+}
+
+// stop stops watching the context.
+func (w *connWatcher) stop() {
+	w.once.Do(func() { close(w.done) })
+}
+
+// connBody closes the connection with the response body.
+type connBody struct {
+	io.ReadCloser
+	conn    net.Conn
+	watcher *connWatcher
+}
+
+func (b *connBody) Close() error {
+	b.watcher.stop()
+	err := b.ReadCloser.Close()
+	b.conn.Close()
+	return err
+}
@@ -0,0 +1,303 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wallarm/gotestwaf/internal/payload/placeholder"
+)
+
+const testChunkedPayload = "<script>alert(1)</script>"
+
+// recordingListener saves all data read from the accepted connections.
+type recordingListener struct {
+	net.Listener
+
+	mu   sync.Mutex
+	data bytes.Buffer
+}
+
+func (l *recordingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &recordingConn{Conn: conn, l: l}, nil
+}
+
+func (l *recordingListener) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.data.String()
+}
+
+type recordingConn struct {
+	net.Conn
+	l *recordingListener
+}
+
+func (c *recordingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+
+	c.l.mu.Lock()
+	c.l.data.Write(p[:n])
+	c.l.mu.Unlock()
+
+	return n, err
+}
+
+// startRecordingServer starts the HTTP server which saves the raw requests.
+func startRecordingServer(t *testing.T, handler http.Handler) (*httptest.Server, *recordingListener) {
+	srv := httptest.NewUnstartedServer(handler)
+	l := &recordingListener{Listener: srv.Listener}
+	srv.Listener = l
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	return srv, l
+}
+
+// echoHandler responds with the request body.
+func echoHandler(w http.ResponseWriter, r *http.Request) {
+	io.Copy(w, r.Body)
+}
+
+func sendChunked(t *testing.T, tr http.RoundTripper, requestURL, spec string) string {
+	req, err := placeholder.Apply(requestURL, spec, testChunkedPayload)
+	if err != nil {
+		t.Fatalf("%s: couldn't create request: %v", spec, err)
+	}
+
+	client := &http.Client{Transport: tr}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("%s: got an error while testing: %v", spec, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("%s: couldn't read response: %v", spec, err)
+	}
+
+	return string(body)
+}
+
+func TestChunkedTransportWire(t *testing.T) {
+	srv, l := startRecordingServer(t, http.HandlerFunc(echoHandler))
+
+	tr := &chunkedTransport{Transport: &http.Transport{}}
+
+	if body := sendChunked(t, tr, srv.URL, `RequestBody(chunked=10,chunkExtension="a=b")`); body != testChunkedPayload {
+		t.Fatalf("got body %q, want %q", body, testChunkedPayload)
+	}
+
+	wantChunks := "a;a=b\r\n<script>al\r\na;a=b\r\nert(1)</sc\r\n5;a=b\r\nript>\r\n0\r\n\r\n"
+
+	raw := l.String()
+	if !strings.Contains(raw, "\r\nTransfer-Encoding: chunked\r\n") || !strings.HasSuffix(raw, "\r\n\r\n"+wantChunks) {
+		t.Fatalf("got request on the wire:\n%q\nwant chunks %q", raw, wantChunks)
+	}
+	if strings.Contains(raw, "Content-Length") {
+		t.Fatalf("got Content-Length with the chunked body:\n%q", raw)
+	}
+}
+
+func TestChunkedTransportChunkSize(t *testing.T) {
+	srv, l := startRecordingServer(t, http.HandlerFunc(echoHandler))
+
+	tr := &chunkedTransport{Transport: &http.Transport{}}
+
+	if body := sendChunked(t, tr, srv.URL, "RequestBody(chunked=10)"); body != testChunkedPayload {
+		t.Fatalf("got body %q, want %q", body, testChunkedPayload)
+	}
+
+	raw := l.String()
+	for _, chunk := range []string{"\r\na\r\n<script>al\r\n", "\r\na\r\nert(1)</sc\r\n", "\r\n5\r\nript>\r\n", "\r\n0\r\n\r\n"} {
+		if !strings.Contains(raw, chunk) {
+			t.Fatalf("chunk %q isn't found in the request on the wire:\n%q", chunk, raw)
+		}
+	}
+}
+
+func TestChunkedTransportRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/target", echoHandler)
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		http.Redirect(w, r, "/target", http.StatusTemporaryRedirect)
+	})
+
+	srv, _ := startRecordingServer(t, mux)
+
+	tr := &chunkedTransport{Transport: &http.Transport{}}
+
+	for _, spec := range []string{
+		`RequestBody(chunked=4,chunkExtension="a=b")`,
+		"RequestBody(chunked=4)",
+	} {
+		if body := sendChunked(t, tr, srv.URL+"/redirect", spec); body != testChunkedPayload {
+			t.Fatalf("%s: got body %q after redirect, want %q", spec, body, testChunkedPayload)
+		}
+	}
+}
+
+func TestChunkedTransportDialContext(t *testing.T) {
+	srv, _ := startRecordingServer(t, http.HandlerFunc(echoHandler))
+
+	var dialed []string
+	var d net.Dialer
+
+	tr := &chunkedTransport{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = append(dialed, addr)
+			return d.DialContext(ctx, network, addr)
+		},
+	}}
+
+	sendChunked(t, tr, srv.URL, `RequestBody(chunked=4,chunkExtension="a=b")`)
+
+	if len(dialed) != 1 || dialed[0] != srv.Listener.Addr().String() {
+		t.Fatalf("got dialed addresses %v, want %s", dialed, srv.Listener.Addr())
+	}
+}
+
+// connectProxy is the HTTP proxy which supports only the CONNECT method.
+type connectProxy struct {
+	mu    sync.Mutex
+	auth  []string
+	hosts []string
+}
+
+func (p *connectProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodConnect {
+		http.Error(w, "only CONNECT is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	p.mu.Lock()
+	p.auth = append(p.auth, r.Header.Get("Proxy-Authorization"))
+	p.hosts = append(p.hosts, r.Host)
+	p.mu.Unlock()
+
+	target, err := net.Dial("tcp", r.Host)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer target.Close()
+
+	conn, rw, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	rw.WriteString("HTTP/1.1 200 Connection established\r\n\r\n")
+	rw.Flush()
+
+	go io.Copy(target, rw)
+	io.Copy(conn, target)
+}
+
+func TestChunkedTransportProxy(t *testing.T) {
+	srv, l := startRecordingServer(t, http.HandlerFunc(echoHandler))
+
+	proxy := &connectProxy{}
+	proxySrv := httptest.NewServer(proxy)
+	defer proxySrv.Close()
+
+	proxyURL, err := url.Parse(proxySrv.URL)
+	if err != nil {
+		t.Fatalf("couldn't parse proxy URL: %v", err)
+	}
+	proxyURL.User = url.UserPassword("user", "secret")
+
+	tr := &chunkedTransport{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+
+	if body := sendChunked(t, tr, srv.URL, `RequestBody(chunked=10,chunkExtension="a=b")`); body != testChunkedPayload {
+		t.Fatalf("got body %q, want %q", body, testChunkedPayload)
+	}
+
+	wantAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret"))
+	if len(proxy.hosts) != 1 || proxy.hosts[0] != srv.Listener.Addr().String() || proxy.auth[0] != wantAuth {
+		t.Fatalf("got CONNECT to %v with auth %v", proxy.hosts, proxy.auth)
+	}
+
+	// the chunks are sent through the tunnel as is
+	if raw := l.String(); !strings.Contains(raw, "a;a=b\r\n<script>al\r\n") {
+		t.Fatalf("chunk extensions aren't found in the request on the wire:\n%q", raw)
+	}
+
+	tr.Proxy = http.ProxyURL(&url.URL{Scheme: "socks5", Host: proxySrv.Listener.Addr().String()})
+
+	req, err := placeholder.Apply(srv.URL, `RequestBody(chunked=4,chunkExtension="a=b")`, testChunkedPayload)
+	if err != nil {
+		t.Fatalf("couldn't create request: %v", err)
+	}
+	if _, err = tr.RoundTrip(req); err == nil {
+		t.Fatalf("request is sent through the SOCKS proxy")
+	}
+}
+
+func TestChunkedTransportCancel(t *testing.T) {
+	// the server reads the request, but doesn't respond until the test ends
+	release := make(chan struct{})
+	defer close(release)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("couldn't listen: %v", err)
+	}
+	defer lis.Close()
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		http.ReadRequest(bufio.NewReader(conn))
+		<-release
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	req, err := placeholder.Apply("http://"+lis.Addr().String(), `RequestBody(chunked=4,chunkExtension="a=b")`, testChunkedPayload)
+	if err != nil {
+		t.Fatalf("couldn't create request: %v", err)
+	}
+
+	tr := &chunkedTransport{Transport: &http.Transport{}}
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := tr.RoundTrip(req.WithContext(ctx))
+		errCh <- err
+	}()
+
+	select {
+	case err = <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("got error %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("request isn't canceled")
+	}
+}
@@ -10,39 +10,57 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/net/http2"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/dynamicpb"
 
 	"github.com/wallarm/gotestwaf/internal/config"
 	"github.com/wallarm/gotestwaf/internal/payload/encoder"
-	grpcPlaceholder "github.com/wallarm/gotestwaf/internal/payload/placeholder/grpc"
+	"github.com/wallarm/gotestwaf/internal/payload/placeholder"
 )
 
 const (
 	grpcContentType            = "application/grpc"
 	grpcUserAgent              = "grpc-go/1.42.0"
 	grpcServerDetectionTimeout = 3 * time.Second
+
+	grpcMethodsFromDescriptors = "descriptors"
+	grpcMethodsFromReflection  = "reflection"
+	grpcMethodsBuiltIn         = "built-in"
 )
 
 type GRPCConn struct {
+	logger *logrus.Logger
+
 	host           string
 	transportCreds credentials.TransportCredentials
 	tlsConf        *tls.Config
 
-	conn *grpc.ClientConn
+	// methods are loaded from the user-provided protobuf definitions
+	methods []*grpcMethod
+	filter  *grpcMethodFilter
+
+	mu            sync.Mutex
+	conn          *grpc.ClientConn
+	targets       map[string][]*grpcTarget
+	methodsSource string
 
 	isAvailable bool
 }
 
-func NewGRPCConn(cfg *config.Config) (*GRPCConn, error) {
-	g := &GRPCConn{isAvailable: true}
+func NewGRPCConn(cfg *config.Config, logger *logrus.Logger) (*GRPCConn, error) {
+	g := &GRPCConn{logger: logger, isAvailable: true}
 
 	if cfg.GRPCPort == 0 {
 		g.isAvailable = false
@@ -61,6 +79,18 @@ func NewGRPCConn(cfg *config.Config) (*GRPCConn, error) {
 		g.transportCreds = credentials.NewTLS(g.tlsConf)
 	}
 
+	g.filter, err = newGRPCMethodFilter(cfg.GRPCInclude, cfg.GRPCExclude)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.GRPCProtoFiles) != 0 || len(cfg.GRPCDescriptorSets) != 0 {
+		g.methods, err = loadGRPCMethods(cfg.GRPCProtoFiles, cfg.GRPCImportPaths, cfg.GRPCDescriptorSets)
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't load gRPC methods")
+		}
+	}
+
 	return g, nil
 }
 
@@ -175,86 +205,292 @@ func (g *GRPCConn) CheckAvailability(ctx context.Context) (bool, error) {
 	return ok, nil
 }
 
-func (g *GRPCConn) Send(ctx context.Context, encoderName, payload string) (body string, statusCode int, err error) {
-	if !g.isAvailable {
-		return "", 0, nil
+// Targets returns all gRPC methods and fields which are used to inject payloads
+// by the placeholder. Methods are loaded from the user-provided protobuf definitions or discovered
+// with the server reflection. If neither is available, the built-in
+// ServiceFooBar service is used. Methods are filtered by the include and
+// exclude patterns. The name option of the metadata placeholders sets the
+// metadata key of the targets, e.g. gRPCMetadata(name=authorization).
+func (g *GRPCConn) Targets(ctx context.Context, placeholderSpec string) ([]*grpcTarget, error) {
+	ph, config, err := placeholder.Lookup(placeholderSpec)
+	if err != nil {
+		return nil, err
 	}
 
-	encodedPayload, err := encoder.Apply(encoderName, payload)
+	err = g.connect(ctx)
 	if err != nil {
-		return "", 0, errors.Wrap(err, "encoding payload")
+		return nil, err
+	}
+
+	targets := g.targets[ph.GetName()]
+
+	conf, ok := config.(*placeholder.GRPCMetadataConfig)
+	if !ok {
+		return targets, nil
+	}
+
+	// the cached targets are shared by all test cases
+	keyTargets := make([]*grpcTarget, 0, len(targets))
+	for _, target := range targets {
+		t := *target
+		t.metadataKey = conf.Name
+		keyTargets = append(keyTargets, &t)
+	}
+
+	return keyTargets, nil
+}
+
+// MethodsSource returns where the list of tested methods was obtained from:
+// "descriptors", "reflection" or "built-in".
+func (g *GRPCConn) MethodsSource() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.methodsSource
+}
+
+// connect sets up a connection to the server and loads the list of targets.
+func (g *GRPCConn) connect(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.conn != nil {
+		return nil
 	}
 
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, grpcServerDetectionTimeout)
 	defer cancel()
 
-	// Set up a connection to the server.
-	if g.conn == nil {
-		var conn *grpc.ClientConn
+	var (
+		conn *grpc.ClientConn
+		err  error
+	)
+
+	switch g.transportCreds {
+	case nil:
+		conn, err = grpc.DialContext(ctxWithTimeout, g.host, grpc.WithInsecure(), grpc.WithBlock())
+	default:
+		conn, err = grpc.DialContext(ctxWithTimeout, g.host, grpc.WithTransportCredentials(g.transportCreds), grpc.WithBlock())
+	}
+	if err != nil {
+		return errors.Wrap(err, "sending gRPC request")
+	}
+
+	methods := g.methods
+	g.methodsSource = grpcMethodsFromDescriptors
+
+	if len(methods) == 0 {
+		methods, err = reflectGRPCMethods(ctxWithTimeout, conn)
+		if err == nil && len(methods) != 0 {
+			g.methodsSource = grpcMethodsFromReflection
+		} else {
+			if err == nil {
+				err = errors.New("no methods found")
+			}
+			g.logger.WithError(err).Warn("gRPC reflection failed, falling back to the built-in ServiceFooBar service")
 
-		switch g.transportCreds {
-		case nil:
-			conn, err = grpc.DialContext(ctxWithTimeout, g.host, grpc.WithInsecure(), grpc.WithBlock())
-		default:
-			conn, err = grpc.DialContext(ctxWithTimeout, g.host, grpc.WithTransportCredentials(g.transportCreds), grpc.WithBlock())
+			methods = defaultGRPCMethods()
+			g.methodsSource = grpcMethodsBuiltIn
 		}
-		if err != nil {
-			return "", 0, errors.Wrap(err, "sending gRPC request")
+	}
+
+	methods = g.filter.apply(methods)
+
+	g.targets = make(map[string][]*grpcTarget)
+	for name, ph := range placeholder.Placeholders {
+		if _, ok := ph.(placeholder.GRPC); ok {
+			g.targets[name] = grpcTargets(name, methods)
+		}
+	}
+	g.conn = conn
+
+	return nil
+}
+
+// Send injects the encoded payload into the target and calls the target method.
+func (g *GRPCConn) Send(ctx context.Context, target *grpcTarget, encoderName, payload string) (*grpcResponse, error) {
+	if !g.isAvailable {
+		return &grpcResponse{status: status.New(codes.Unavailable, "")}, nil
+	}
+
+	encodedPayload, err := encoder.Apply(encoderName, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding payload")
+	}
+
+	err = g.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch target.placeholder {
+	case placeholder.DefaultGRPCMetadata.GetName(), placeholder.DefaultGRPCBinaryMetadata.GetName():
+		key := target.metadataKey
+		if key == "" {
+			randomKey, err := placeholder.RandomHex(placeholder.Seed)
+			if err != nil {
+				return nil, err
+			}
+
+			key = "x-" + randomKey
 		}
 
-		g.conn = conn
+		// the values of keys with the -bin suffix are sent as binary data
+		if target.placeholder == placeholder.DefaultGRPCBinaryMetadata.GetName() && !strings.HasSuffix(key, "-bin") {
+			key += "-bin"
+		}
+
+		ctx = metadata.AppendToOutgoingContext(ctx, key, encodedPayload)
+	}
+
+	grpcResp := &grpcResponse{}
+
+	if target.isStreaming() {
+		err = g.invokeStream(ctx, target, encodedPayload, grpcResp)
+	} else {
+		err = g.invoke(ctx, target, encodedPayload, grpcResp)
+	}
+
+	grpcResp.status = status.Convert(err)
+	grpcResp.statusCode = grpcCodeToHTTPStatus(grpcResp.status.Code())
+
+	if _, ok := status.FromError(err); !ok {
+		return nil, err
+	}
+
+	return grpcResp, nil
+}
+
+// invoke calls the unary method and saves the response message, metadata and
+// trailers to the grpcResp.
+func (g *GRPCConn) invoke(ctx context.Context, target *grpcTarget, payload string, grpcResp *grpcResponse) error {
+	resp := target.newResponse()
+
+	err := g.conn.Invoke(ctx, target.method.fullMethod, target.newRequest(payload), resp,
+		grpc.Header(&grpcResp.header), grpc.Trailer(&grpcResp.trailer))
+	if err != nil {
+		return err
+	}
+
+	respBody, err := protojson.Marshal(resp)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode response")
+	}
+
+	grpcResp.body = string(respBody)
+
+	return nil
+}
+
+// invokeStream calls the streaming method. The payload is split across several
+// request messages of the client-streaming and bidirectional streaming methods,
+// and is sent in a single request message of the server-streaming methods.
+// All response messages, metadata and trailers are saved to the grpcResp.
+func (g *GRPCConn) invokeStream(ctx context.Context, target *grpcTarget, payload string, grpcResp *grpcResponse) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	desc := &grpc.StreamDesc{
+		ClientStreams: target.method.clientStreaming,
+		ServerStreams: target.method.serverStreaming,
 	}
 
-	client := grpcPlaceholder.NewServiceFooBarClient(g.conn)
+	stream, err := g.conn.NewStream(ctx, desc, target.method.fullMethod)
+	if err != nil {
+		return err
+	}
+
+	reqs := []*dynamicpb.Message{target.newRequest(payload)}
+	if target.method.clientStreaming {
+		reqs = target.newStreamRequests(payload)
+	}
 
-	resp, err := client.Foo(ctx, &grpcPlaceholder.Request{Value: encodedPayload})
+	for _, req := range reqs {
+		// io.EOF means that the server has closed the stream,
+		// the status will be received by RecvMsg
+		if err = stream.SendMsg(req); err != nil {
+			break
+		}
+	}
+
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	err = stream.CloseSend()
 	if err != nil {
-		st := status.Convert(err)
-
-		// gRPC status code converting to HTTP status code
-		switch st.Code() {
-		case codes.OK:
-			statusCode = 200
-		case codes.Canceled:
-			statusCode = 499
-		case codes.Unknown:
-			statusCode = 500
-		case codes.InvalidArgument:
-			statusCode = 400
-		case codes.DeadlineExceeded:
-			statusCode = 504
-		case codes.NotFound:
-			statusCode = 404
-		case codes.AlreadyExists:
-			statusCode = 409
-		case codes.PermissionDenied:
-			statusCode = 403
-		case codes.ResourceExhausted:
-			statusCode = 429
-		case codes.FailedPrecondition:
-			statusCode = 400
-		case codes.Aborted:
-			statusCode = 409
-		case codes.OutOfRange:
-			statusCode = 400
-		case codes.Unimplemented:
-			statusCode = 501
-		case codes.Internal:
-			statusCode = 500
-		case codes.Unavailable:
-			statusCode = 503
-		case codes.DataLoss:
-			statusCode = 500
-		case codes.Unauthenticated:
-			statusCode = 401
-		default:
-			statusCode = 500
+		return err
+	}
+
+	var respBodies []string
+
+	for {
+		resp := target.newResponse()
+
+		err = stream.RecvMsg(resp)
+		if err != nil {
+			break
 		}
 
-		return "", statusCode, nil
+		respBody, err := protojson.Marshal(resp)
+		if err != nil {
+			return errors.Wrap(err, "couldn't decode response")
+		}
+
+		respBodies = append(respBodies, string(respBody))
+	}
+
+	grpcResp.header, _ = stream.Header()
+	grpcResp.trailer = stream.Trailer()
+	grpcResp.body = strings.Join(respBodies, "\n")
+
+	if err == io.EOF {
+		return nil
 	}
 
-	return resp.GetValue(), 200, nil
+	return err
+}
+
+// grpcCodeToHTTPStatus converts gRPC status code to HTTP status code.
+func grpcCodeToHTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return 200
+	case codes.Canceled:
+		return 499
+	case codes.Unknown:
+		return 500
+	case codes.InvalidArgument:
+		return 400
+	case codes.DeadlineExceeded:
+		return 504
+	case codes.NotFound:
+		return 404
+	case codes.AlreadyExists:
+		return 409
+	case codes.PermissionDenied:
+		return 403
+	case codes.ResourceExhausted:
+		return 429
+	case codes.FailedPrecondition:
+		return 400
+	case codes.Aborted:
+		return 409
+	case codes.OutOfRange:
+		return 400
+	case codes.Unimplemented:
+		return 501
+	case codes.Internal:
+		return 500
+	case codes.Unavailable:
+		return 503
+	case codes.DataLoss:
+		return 500
+	case codes.Unauthenticated:
+		return 401
+	default:
+		return 500
+	}
 }
 
 func (g *GRPCConn) IsAvailable() bool {
@@ -262,6 +498,9 @@ func (g *GRPCConn) IsAvailable() bool {
 }
 
 func (g *GRPCConn) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	if g.conn == nil {
 		return nil
 	}
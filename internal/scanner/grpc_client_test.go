@@ -0,0 +1,248 @@
+package scanner
+
+import (
+	"context"
+	"io"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	grpcPlaceholder "github.com/wallarm/gotestwaf/internal/payload/placeholder/grpc"
+)
+
+// startGRPCServer starts the gRPC server on the random local port.
+func startGRPCServer(t *testing.T, server *grpc.Server) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("couldn't listen: %v", err)
+	}
+
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+func dialGRPC(t *testing.T, ctx context.Context, addr string) *grpc.ClientConn {
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("couldn't connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestReflectGRPCMethods(t *testing.T) {
+	server := grpc.NewServer()
+	grpcPlaceholder.RegisterServiceFooBarServer(server, grpcPlaceholder.UnimplementedServiceFooBarServer{})
+	reflection.Register(server)
+
+	addr := startGRPCServer(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	methods, err := reflectGRPCMethods(ctx, dialGRPC(t, ctx, addr))
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	var names []string
+	for _, method := range methods {
+		names = append(names, method.fullMethod)
+
+		if method.input.FullName() != "encoder.Request" || method.output.FullName() != "encoder.Response" {
+			t.Fatalf("%s: got messages %s and %s", method.fullMethod, method.input.FullName(), method.output.FullName())
+		}
+	}
+	sort.Strings(names)
+
+	want := []string{"/encoder.ServiceFooBar/foo", "/encoder.ServiceFooBar/fooBidiStream", "/encoder.ServiceFooBar/fooClientStream"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("got methods %v, want %v", names, want)
+	}
+}
+
+func TestReflectGRPCMethodsFallback(t *testing.T) {
+	server := grpc.NewServer()
+	grpcPlaceholder.RegisterServiceFooBarServer(server, grpcPlaceholder.UnimplementedServiceFooBarServer{})
+
+	addr := startGRPCServer(t, server)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	g := &GRPCConn{logger: logger, host: addr, filter: &grpcMethodFilter{}, isAvailable: true}
+	defer g.Close()
+
+	targets, err := g.Targets(context.Background(), "gRPC")
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	if g.MethodsSource() != grpcMethodsBuiltIn {
+		t.Fatalf("got methods source %q, want %q", g.MethodsSource(), grpcMethodsBuiltIn)
+	}
+	if len(targets) != 1 || targets[0].String() != "/encoder.ServiceFooBar/foo value" {
+		t.Fatalf("got targets %v", targets)
+	}
+}
+
+func TestSendServerStream(t *testing.T) {
+	const payload = "PAYLOAD"
+
+	method := testGRPCMethods(t)["/test.v1.Test/ServerStream"]
+
+	// the server echoes the name field of the request in two response messages
+	server := grpc.NewServer(grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+		req := dynamicpb.NewMessage(method.input)
+		if err := stream.RecvMsg(req); err != nil {
+			return err
+		}
+
+		name := req.Get(method.input.Fields().ByName("name"))
+
+		for i := 0; i < 2; i++ {
+			resp := dynamicpb.NewMessage(method.output)
+			resp.Set(method.output.Fields().ByName("value"), name)
+
+			if err := stream.SendMsg(resp); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	addr := startGRPCServer(t, server)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	g := &GRPCConn{
+		logger:      logger,
+		host:        addr,
+		methods:     []*grpcMethod{method},
+		filter:      &grpcMethodFilter{},
+		isAvailable: true,
+	}
+	defer g.Close()
+
+	targets, err := g.Targets(context.Background(), "gRPC")
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	var target *grpcTarget
+	for _, tg := range targets {
+		if tg.String() == "/test.v1.Test/ServerStream name" {
+			target = tg
+		}
+	}
+	if target == nil {
+		t.Fatalf("server-streaming method isn't targeted: %v", targets)
+	}
+
+	resp, err := g.Send(context.Background(), target, "Plain", payload)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	if resp.statusCode != 200 {
+		t.Fatalf("got status %s", resp.status)
+	}
+
+	want := `{"value":"PAYLOAD"}` + "\n" + `{"value":"PAYLOAD"}`
+	if strings.ReplaceAll(resp.body, " ", "") != want {
+		t.Fatalf("got body %q, want %q", resp.body, want)
+	}
+}
+
+func TestSendMetadataKey(t *testing.T) {
+	const payload = "PAYLOAD"
+
+	// the server saves the keys of the metadata with the payload
+	keys := make(chan string, 1)
+	server := grpc.NewServer(grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		for key, values := range md {
+			if len(values) == 1 && values[0] == payload {
+				keys <- key
+			}
+		}
+		return status.Error(codes.PermissionDenied, "blocked")
+	}))
+
+	addr := startGRPCServer(t, server)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	g := &GRPCConn{
+		logger:      logger,
+		host:        addr,
+		methods:     defaultGRPCMethods(),
+		filter:      &grpcMethodFilter{},
+		isAvailable: true,
+	}
+	defer g.Close()
+
+	tests := []struct {
+		spec string
+		key  string
+	}{
+		{"gRPCMetadata(name=X-Tenant-ID)", "^x-tenant-id$"},
+		{"gRPCMetadata(name=authorization)", "^authorization$"},
+		{"gRPCBinaryMetadata(name=x-token)", "^x-token-bin$"},
+		{"gRPCBinaryMetadata(name=x-token-bin)", "^x-token-bin$"},
+		{"gRPCMetadata", "^x-[0-9a-f]+$"},
+		{"gRPCBinaryMetadata", "^x-[0-9a-f]+-bin$"},
+	}
+
+	for _, tt := range tests {
+		targets, err := g.Targets(context.Background(), tt.spec)
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", tt.spec, err)
+		}
+		if len(targets) != 1 {
+			t.Fatalf("%s: got targets %v", tt.spec, targets)
+		}
+
+		resp, err := g.Send(context.Background(), targets[0], "Plain", payload)
+		if err != nil {
+			t.Fatalf("%s: got an error while testing: %v", tt.spec, err)
+		}
+		if resp.status.Code() != codes.PermissionDenied {
+			t.Fatalf("%s: got status %s", tt.spec, resp.status)
+		}
+
+		var key string
+		select {
+		case key = <-keys:
+		default:
+			t.Fatalf("%s: payload isn't found in the metadata", tt.spec)
+		}
+
+		if !regexp.MustCompile(tt.key).MatchString(key) {
+			t.Fatalf("%s: got metadata key %q, want %q", tt.spec, key, tt.key)
+		}
+	}
+
+	for _, spec := range []string{"gRPCMetadata(name=grpc-timeout)", "gRPCMetadata(name=x key)", "gRPC(name=x)"} {
+		if _, err := g.Targets(context.Background(), spec); err == nil {
+			t.Fatalf("%s: invalid placeholder is accepted", spec)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// loadGRPCMethods parses the given .proto files and binary FileDescriptorSet
+// files (protoc --descriptor_set_out) and returns all methods of all
+// services defined in them.
+func loadGRPCMethods(protoFiles, importPaths, descriptorSets []string) ([]*grpcMethod, error) {
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+
+	for _, fileName := range descriptorSets {
+		err := loadDescriptorSet(files, fileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(protoFiles) != 0 {
+		parser := protoparse.Parser{
+			ImportPaths:      importPaths,
+			InferImportPaths: len(importPaths) == 0,
+		}
+
+		fds, err := parser.ParseFiles(protoFiles...)
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't parse .proto files")
+		}
+
+		for _, fd := range fds {
+			addProtoFile(files, fd)
+		}
+	}
+
+	registry, err := newFileRegistry(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var methods []*grpcMethod
+
+	registry.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			if _, ok := ignoredGRPCServices[string(services.Get(i).FullName())]; ok {
+				continue
+			}
+
+			methods = append(methods, serviceMethods(services.Get(i))...)
+		}
+
+		return true
+	})
+
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].fullMethod < methods[j].fullMethod
+	})
+
+	if len(methods) == 0 {
+		return nil, errors.New("no gRPC methods found in the provided protobuf definitions")
+	}
+
+	return methods, nil
+}
+
+// loadDescriptorSet reads the binary FileDescriptorSet and adds its files
+// to the files.
+func loadDescriptorSet(files map[string]*descriptorpb.FileDescriptorProto, fileName string) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return errors.Wrap(err, "couldn't read descriptor set")
+	}
+
+	fileSet := &descriptorpb.FileDescriptorSet{}
+
+	err = proto.Unmarshal(data, fileSet)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't decode descriptor set %s", fileName)
+	}
+
+	for _, file := range fileSet.GetFile() {
+		files[file.GetName()] = file
+	}
+
+	return nil
+}
+
+// addProtoFile adds the parsed .proto file and all its dependencies to the files.
+func addProtoFile(files map[string]*descriptorpb.FileDescriptorProto, fd *desc.FileDescriptor) {
+	if _, ok := files[fd.GetName()]; ok {
+		return
+	}
+
+	files[fd.GetName()] = fd.AsFileDescriptorProto()
+
+	for _, dep := range fd.GetDependencies() {
+		addProtoFile(files, dep)
+	}
+}
+
+// grpcMethodFilter selects gRPC methods by their names.
+type grpcMethodFilter struct {
+	include []string
+	exclude []string
+}
+
+// newGRPCMethodFilter checks the patterns and creates a new filter. Patterns
+// use the path.Match syntax and are matched against the "package.Service/Method"
+// and "package.Service" names, e.g. "shop.v1.Cart/*" or "shop.v1.*".
+func newGRPCMethodFilter(include, exclude []string) (*grpcMethodFilter, error) {
+	for _, pattern := range append(append([]string{}, include...), exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid gRPC method pattern %q: %v", pattern, err)
+		}
+	}
+
+	return &grpcMethodFilter{include: include, exclude: exclude}, nil
+}
+
+// apply returns methods which match any include pattern (or all methods if
+// there are no include patterns) and don't match any exclude pattern.
+func (f *grpcMethodFilter) apply(methods []*grpcMethod) []*grpcMethod {
+	var filtered []*grpcMethod
+
+	for _, method := range methods {
+		if len(f.include) != 0 && !matchGRPCMethod(f.include, method) {
+			continue
+		}
+		if matchGRPCMethod(f.exclude, method) {
+			continue
+		}
+
+		filtered = append(filtered, method)
+	}
+
+	return filtered
+}
+
+// matchGRPCMethod returns true if the method or its service matches any of
+// the patterns.
+func matchGRPCMethod(patterns []string, method *grpcMethod) bool {
+	name := strings.TrimPrefix(method.fullMethod, "/")
+	service := name[:strings.LastIndex(name, "/")]
+
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, service); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,208 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	testShopProto = `syntax = "proto3";
+
+package shop.v1;
+
+import "common/types.proto";
+
+service Cart {
+  rpc Add(common.Item) returns (common.Item);
+  rpc List(common.Item) returns (stream common.Item);
+}
+
+service Orders {
+  rpc Create(common.Item) returns (common.Item);
+}
+`
+
+	testTypesProto = `syntax = "proto3";
+
+package common;
+
+message Item {
+  string name = 1;
+}
+`
+)
+
+// writeTestProtos writes the test .proto files to the temporary directory
+// and returns its path.
+func writeTestProtos(t *testing.T) string {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"shop.proto":         testShopProto,
+		"common/types.proto": testTypesProto,
+	}
+
+	for name, content := range files {
+		fileName := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+			t.Fatalf("couldn't create directory: %v", err)
+		}
+		if err := os.WriteFile(fileName, []byte(content), 0o644); err != nil {
+			t.Fatalf("couldn't write %s: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+func methodNames(methods []*grpcMethod) string {
+	names := make([]string, 0, len(methods))
+	for _, method := range methods {
+		names = append(names, method.fullMethod)
+	}
+
+	return strings.Join(names, ",")
+}
+
+const testShopMethods = "/shop.v1.Cart/Add,/shop.v1.Cart/List,/shop.v1.Orders/Create"
+
+func TestLoadGRPCMethodsProto(t *testing.T) {
+	dir := writeTestProtos(t)
+
+	methods, err := loadGRPCMethods([]string{"shop.proto"}, []string{dir}, nil)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	if got := methodNames(methods); got != testShopMethods {
+		t.Fatalf("got methods %s, want %s", got, testShopMethods)
+	}
+
+	list := methods[1]
+	if list.clientStreaming || !list.serverStreaming {
+		t.Fatalf("%s: got client streaming %v, server streaming %v", list.fullMethod, list.clientStreaming, list.serverStreaming)
+	}
+	if list.input.FullName() != "common.Item" {
+		t.Fatalf("%s: got input %s", list.fullMethod, list.input.FullName())
+	}
+
+	if _, err = loadGRPCMethods([]string{"shop.proto"}, []string{t.TempDir()}, nil); err == nil {
+		t.Fatalf("missing .proto file is loaded")
+	}
+}
+
+func TestLoadGRPCMethodsDescriptorSet(t *testing.T) {
+	dir := writeTestProtos(t)
+
+	parser := protoparse.Parser{ImportPaths: []string{dir}}
+
+	fds, err := parser.ParseFiles("shop.proto")
+	if err != nil {
+		t.Fatalf("couldn't parse shop.proto: %v", err)
+	}
+
+	fileSet := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			fds[0].GetDependencies()[0].AsFileDescriptorProto(),
+			fds[0].AsFileDescriptorProto(),
+		},
+	}
+
+	data, err := proto.Marshal(fileSet)
+	if err != nil {
+		t.Fatalf("couldn't marshal descriptor set: %v", err)
+	}
+
+	fileName := filepath.Join(dir, "shop.pb")
+	if err = os.WriteFile(fileName, data, 0o644); err != nil {
+		t.Fatalf("couldn't write descriptor set: %v", err)
+	}
+
+	methods, err := loadGRPCMethods(nil, nil, []string{fileName})
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	if got := methodNames(methods); got != testShopMethods {
+		t.Fatalf("got methods %s, want %s", got, testShopMethods)
+	}
+
+	// the descriptor set without the dependency
+	fileSet.File = fileSet.File[1:]
+
+	data, err = proto.Marshal(fileSet)
+	if err != nil {
+		t.Fatalf("couldn't marshal descriptor set: %v", err)
+	}
+	if err = os.WriteFile(fileName, data, 0o644); err != nil {
+		t.Fatalf("couldn't write descriptor set: %v", err)
+	}
+
+	if _, err = loadGRPCMethods(nil, nil, []string{fileName}); err == nil {
+		t.Fatalf("descriptor set with missing dependencies is loaded")
+	}
+
+	if err = os.WriteFile(fileName, []byte("not a descriptor set"), 0o644); err != nil {
+		t.Fatalf("couldn't write descriptor set: %v", err)
+	}
+
+	if _, err = loadGRPCMethods(nil, nil, []string{fileName}); err == nil {
+		t.Fatalf("invalid descriptor set is loaded")
+	}
+}
+
+func TestLoadGRPCMethodsNoServices(t *testing.T) {
+	dir := writeTestProtos(t)
+
+	_, err := loadGRPCMethods([]string{"common/types.proto"}, []string{dir}, nil)
+	if err == nil {
+		t.Fatalf("no error for the file without services")
+	}
+}
+
+func TestGRPCMethodFilter(t *testing.T) {
+	var methods []*grpcMethod
+	for _, name := range strings.Split(testShopMethods, ",") {
+		methods = append(methods, &grpcMethod{fullMethod: name})
+	}
+
+	tests := []struct {
+		include []string
+		exclude []string
+		want    string
+	}{
+		{nil, nil, testShopMethods},
+		{[]string{"shop.v1.Cart"}, nil, "/shop.v1.Cart/Add,/shop.v1.Cart/List"},
+		{[]string{"shop.v1.Cart/*"}, nil, "/shop.v1.Cart/Add,/shop.v1.Cart/List"},
+		{[]string{"shop.v1.*"}, nil, testShopMethods},
+		{[]string{"shop.v1.Cart/Add", "shop.v1.Orders"}, nil, "/shop.v1.Cart/Add,/shop.v1.Orders/Create"},
+		{nil, []string{"shop.v1.Cart/L*"}, "/shop.v1.Cart/Add,/shop.v1.Orders/Create"},
+		{[]string{"shop.v1.*"}, []string{"shop.v1.Orders"}, "/shop.v1.Cart/Add,/shop.v1.Cart/List"},
+		{[]string{"other.*"}, nil, ""},
+	}
+
+	for _, tt := range tests {
+		filter, err := newGRPCMethodFilter(tt.include, tt.exclude)
+		if err != nil {
+			t.Fatalf("include %v, exclude %v: got an error while testing: %v", tt.include, tt.exclude, err)
+		}
+
+		if got := methodNames(filter.apply(methods)); got != tt.want {
+			t.Fatalf("include %v, exclude %v: got methods %q, want %q", tt.include, tt.exclude, got, tt.want)
+		}
+	}
+
+	if _, err := newGRPCMethodFilter([]string{"shop.v1.[Cart"}, nil); err == nil {
+		t.Fatalf("invalid pattern is accepted")
+	}
+	if _, err := newGRPCMethodFilter(nil, []string{"shop.v1.[Cart"}); err == nil {
+		t.Fatalf("invalid pattern is accepted")
+	}
+}
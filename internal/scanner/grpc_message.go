@@ -0,0 +1,362 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/wallarm/gotestwaf/internal/payload/placeholder"
+)
+
+const (
+	// maxGRPCFieldDepth limits the nesting level of the message fields used to
+	// inject payloads and filled with placeholder values. It also protects from
+	// infinite recursion in case of recursive message types.
+	maxGRPCFieldDepth = 5
+
+	// grpcPlaceholderString is a value of string and bytes fields which are not
+	// used to inject a payload.
+	grpcPlaceholderString = "test"
+
+	// grpcStreamMessages is the number of messages the payload is split into
+	// by the streaming placeholders.
+	grpcStreamMessages = 3
+)
+
+// grpcTarget is the gRPC method and the path to the string or bytes field
+// of the method request message into which a payload is injected. Targets of
+// the metadata placeholders have no field, the metadata key is set if the
+// placeholder has the name option.
+type grpcTarget struct {
+	placeholder string
+	method      *grpcMethod
+	field       []protoreflect.FieldDescriptor
+	metadataKey string
+}
+
+// String returns the target description in the "/package.Service/Method field.path"
+// format.
+func (t *grpcTarget) String() string {
+	if len(t.field) == 0 {
+		return t.method.fullMethod
+	}
+
+	names := make([]string, 0, len(t.field))
+	for _, fd := range t.field {
+		names = append(names, string(fd.Name()))
+	}
+
+	return fmt.Sprintf("%s %s", t.method.fullMethod, strings.Join(names, "."))
+}
+
+// newRequest creates a new request message of the target method with
+// the payload placed into the target field. All other fields are filled
+// with placeholder values to make the message valid.
+func (t *grpcTarget) newRequest(payload string) *dynamicpb.Message {
+	req := dynamicpb.NewMessage(t.method.input)
+
+	fillMessage(req, t.field, 0)
+
+	if len(t.field) == 0 {
+		return req
+	}
+
+	var msg protoreflect.Message = req
+	last := len(t.field) - 1
+
+	for _, fd := range t.field[:last] {
+		if fd.IsList() {
+			msg = msg.Mutable(fd).List().Get(0).Message()
+		} else {
+			msg = msg.Mutable(fd).Message()
+		}
+	}
+
+	fd := t.field[last]
+
+	kind := fd.Kind()
+	if fd.IsMap() {
+		kind = fd.MapValue().Kind()
+	}
+
+	value := protoreflect.ValueOfString(payload)
+	if kind == protoreflect.BytesKind {
+		value = protoreflect.ValueOfBytes([]byte(payload))
+	}
+
+	switch {
+	case fd.IsMap():
+		msg.Mutable(fd).Map().Set(placeholderValue(fd.MapKey()).MapKey(), value)
+	case fd.IsList():
+		msg.Mutable(fd).List().Set(0, value)
+	default:
+		msg.Set(fd, value)
+	}
+
+	return req
+}
+
+// newStreamRequests splits the payload into several parts and creates
+// a request message for each of them.
+func (t *grpcTarget) newStreamRequests(payload string) []*dynamicpb.Message {
+	var reqs []*dynamicpb.Message
+
+	for _, part := range placeholder.SplitPayload(payload, grpcStreamMessages) {
+		reqs = append(reqs, t.newRequest(part))
+	}
+
+	return reqs
+}
+
+// isStreaming returns true if the method is called with a stream.
+func (t *grpcTarget) isStreaming() bool {
+	return t.method.clientStreaming || t.method.serverStreaming
+}
+
+// newResponse creates a new empty response message of the target method.
+func (t *grpcTarget) newResponse() *dynamicpb.Message {
+	return dynamicpb.NewMessage(t.method.output)
+}
+
+// fillMessage sets placeholder values to all fields of the message. Only one
+// field of each oneof is set: the one on the target path if any, or the first one.
+// Repeated fields and maps get a single element.
+func fillMessage(msg protoreflect.Message, target []protoreflect.FieldDescriptor, depth int) {
+	if depth >= maxGRPCFieldDepth {
+		return
+	}
+
+	md := msg.Descriptor()
+
+	// well-known google.protobuf.Any can't be filled with a random content
+	if md.FullName() == "google.protobuf.Any" {
+		return
+	}
+
+	var targetField protoreflect.FieldDescriptor
+	var nextTarget []protoreflect.FieldDescriptor
+	if depth < len(target) {
+		targetField = target[depth]
+		nextTarget = target
+	}
+
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+
+		fd := od.Fields().Get(0)
+		if targetField != nil && targetField.ContainingOneof() == od {
+			fd = targetField
+		}
+
+		fillField(msg, fd, fieldTarget(fd, targetField, nextTarget), depth)
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.ContainingOneof() != nil {
+			continue
+		}
+
+		fillField(msg, fd, fieldTarget(fd, targetField, nextTarget), depth)
+	}
+}
+
+// fieldTarget returns the target path if the field is on it.
+func fieldTarget(fd, targetField protoreflect.FieldDescriptor, target []protoreflect.FieldDescriptor) []protoreflect.FieldDescriptor {
+	if fd == targetField {
+		return target
+	}
+
+	return nil
+}
+
+// fillField sets a placeholder value to the message field.
+func fillField(msg protoreflect.Message, fd protoreflect.FieldDescriptor, target []protoreflect.FieldDescriptor, depth int) {
+	switch {
+	case fd.IsMap():
+		key := placeholderValue(fd.MapKey())
+		if !key.IsValid() {
+			return
+		}
+
+		mapValue := msg.Mutable(fd).Map()
+
+		if fd.MapValue().Kind() == protoreflect.MessageKind {
+			fillMessage(mapValue.Mutable(key.MapKey()).Message(), nil, depth+1)
+			return
+		}
+
+		value := placeholderValue(fd.MapValue())
+		if value.IsValid() {
+			mapValue.Set(key.MapKey(), value)
+		}
+
+	case fd.IsList():
+		list := msg.Mutable(fd).List()
+
+		if fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+			fillMessage(list.AppendMutable().Message(), target, depth+1)
+			return
+		}
+
+		value := placeholderValue(fd)
+		if value.IsValid() {
+			list.Append(value)
+		}
+
+	case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+		// skip optional recursive messages to avoid huge requests
+		if target == nil && depth+1 >= maxGRPCFieldDepth {
+			return
+		}
+
+		fillMessage(msg.Mutable(fd).Message(), target, depth+1)
+
+	default:
+		value := placeholderValue(fd)
+		if value.IsValid() {
+			msg.Set(fd, value)
+		}
+	}
+}
+
+// placeholderValue returns a placeholder value for the scalar field.
+func placeholderValue(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		// the first value is usually "UNSPECIFIED", so use the next one if possible
+		if values.Len() > 1 {
+			return protoreflect.ValueOfEnum(values.Get(1).Number())
+		}
+		return protoreflect.ValueOfEnum(values.Get(0).Number())
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(1)
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(1)
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(1)
+
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(1)
+
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(1)
+
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(1)
+
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(grpcPlaceholderString)
+
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(grpcPlaceholderString))
+	}
+
+	return protoreflect.Value{}
+}
+
+// grpcTargets returns targets of the placeholder for the given methods:
+//   - gRPC: string fields of unary and server-streaming methods;
+//   - gRPCMetadata, gRPCBinaryMetadata: unary and server-streaming methods;
+//   - gRPCClientStream: string fields of client-streaming methods;
+//   - gRPCBidiStream: string fields of bidirectional streaming methods.
+func grpcTargets(placeholderName string, methods []*grpcMethod) []*grpcTarget {
+	var targets []*grpcTarget
+
+	for _, method := range methods {
+		// server-streaming methods get a single request message as unary ones
+		singleRequest := !method.clientStreaming
+
+		switch placeholderName {
+		case placeholder.DefaultGRPCMetadata.GetName(), placeholder.DefaultGRPCBinaryMetadata.GetName():
+			if singleRequest {
+				targets = append(targets, &grpcTarget{
+					placeholder: placeholderName,
+					method:      method,
+				})
+			}
+			continue
+
+		case placeholder.DefaultGRPC.GetName():
+			if !singleRequest {
+				continue
+			}
+
+		case placeholder.DefaultGRPCClientStream.GetName():
+			if !method.clientStreaming || method.serverStreaming {
+				continue
+			}
+
+		case placeholder.DefaultGRPCBidiStream.GetName():
+			if !method.clientStreaming || !method.serverStreaming {
+				continue
+			}
+
+		default:
+			continue
+		}
+
+		for _, field := range payloadFields(method.input) {
+			targets = append(targets, &grpcTarget{
+				placeholder: placeholderName,
+				method:      method,
+				field:       field,
+			})
+		}
+	}
+
+	return targets
+}
+
+// payloadFields returns paths to all string and bytes fields of the message
+// including fields of the nested messages. Maps with string or bytes values
+// are returned too, the payload is placed into the value of a single entry.
+func payloadFields(md protoreflect.MessageDescriptor) [][]protoreflect.FieldDescriptor {
+	var paths [][]protoreflect.FieldDescriptor
+
+	var walk func(md protoreflect.MessageDescriptor, prefix []protoreflect.FieldDescriptor)
+	walk = func(md protoreflect.MessageDescriptor, prefix []protoreflect.FieldDescriptor) {
+		if len(prefix) >= maxGRPCFieldDepth {
+			return
+		}
+
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+
+			path := make([]protoreflect.FieldDescriptor, len(prefix), len(prefix)+1)
+			copy(path, prefix)
+			path = append(path, fd)
+
+			if fd.IsMap() {
+				switch fd.MapValue().Kind() {
+				case protoreflect.StringKind, protoreflect.BytesKind:
+					paths = append(paths, path)
+				}
+				continue
+			}
+
+			switch fd.Kind() {
+			case protoreflect.StringKind, protoreflect.BytesKind:
+				paths = append(paths, path)
+			case protoreflect.MessageKind, protoreflect.GroupKind:
+				walk(fd.Message(), path)
+			}
+		}
+	}
+
+	walk(md, nil)
+
+	return paths
+}
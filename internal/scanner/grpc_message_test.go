@@ -0,0 +1,257 @@
+package scanner
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/wallarm/gotestwaf/internal/payload/placeholder"
+)
+
+const testProto = `syntax = "proto3";
+
+package test.v1;
+
+message Node {
+  string text = 1;
+  int32 count = 2;
+  Node child = 3;
+}
+
+message Request {
+  string name = 1;
+  bytes data = 2;
+  repeated string tags = 3;
+  Node node = 4;
+  repeated Node nodes = 5;
+  map<string, string> labels = 6;
+  map<string, Node> objects = 7;
+  oneof choice {
+    string first = 8;
+    string second = 9;
+  }
+  int64 id = 10;
+}
+
+message Response {
+  string value = 1;
+}
+
+service Test {
+  rpc Unary(Request) returns (Response);
+  rpc ServerStream(Request) returns (stream Response);
+  rpc ClientStream(stream Request) returns (Response);
+  rpc BidiStream(stream Request) returns (stream Response);
+}
+`
+
+// testGRPCMethods parses the test .proto file and returns its methods.
+func testGRPCMethods(t *testing.T) map[string]*grpcMethod {
+	parser := protoparse.Parser{
+		Accessor: protoparse.FileContentsFromMap(map[string]string{"test.proto": testProto}),
+	}
+
+	fds, err := parser.ParseFiles("test.proto")
+	if err != nil {
+		t.Fatalf("couldn't parse test.proto: %v", err)
+	}
+
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+	addProtoFile(files, fds[0])
+
+	registry, err := newFileRegistry(files)
+	if err != nil {
+		t.Fatalf("couldn't build registry: %v", err)
+	}
+
+	d, err := registry.FindDescriptorByName("test.v1.Test")
+	if err != nil {
+		t.Fatalf("couldn't find service: %v", err)
+	}
+
+	methods := make(map[string]*grpcMethod)
+	for _, m := range serviceMethods(d.(protoreflect.ServiceDescriptor)) {
+		methods[m.fullMethod] = m
+	}
+
+	return methods
+}
+
+// fieldPath returns the field path in the "a.b.c" format.
+func fieldPath(path []protoreflect.FieldDescriptor) string {
+	names := make([]string, 0, len(path))
+	for _, fd := range path {
+		names = append(names, string(fd.Name()))
+	}
+
+	return strings.Join(names, ".")
+}
+
+func TestPayloadFields(t *testing.T) {
+	method := testGRPCMethods(t)["/test.v1.Test/Unary"]
+
+	var got []string
+	for _, path := range payloadFields(method.input) {
+		got = append(got, fieldPath(path))
+	}
+
+	want := []string{
+		"name",
+		"data",
+		"tags",
+		"node.text",
+		"node.child.text",
+		"node.child.child.text",
+		"node.child.child.child.text",
+		"nodes.text",
+		"nodes.child.text",
+		"nodes.child.child.text",
+		"nodes.child.child.child.text",
+		"labels",
+		"first",
+		"second",
+	}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("got fields %v, want %v", got, want)
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	const payload = "PAYLOAD"
+
+	method := testGRPCMethods(t)["/test.v1.Test/Unary"]
+
+	for _, path := range payloadFields(method.input) {
+		target := &grpcTarget{placeholder: "gRPC", method: method, field: path}
+		name := fieldPath(path)
+
+		req := target.newRequest(payload)
+
+		data, err := proto.Marshal(req)
+		if err != nil {
+			t.Fatalf("%s: couldn't marshal request: %v", name, err)
+		}
+		if n := strings.Count(string(data), payload); n != 1 {
+			t.Fatalf("%s: payload is found %d times in the request", name, n)
+		}
+
+		var msg protoreflect.Message = req
+		for i, fd := range path {
+			v := msg.Get(fd)
+
+			switch {
+			case fd.IsMap():
+				v = v.Map().Get(placeholderValue(fd.MapKey()).MapKey())
+			case fd.IsList():
+				v = v.List().Get(0)
+			}
+
+			if i < len(path)-1 {
+				msg = v.Message()
+				continue
+			}
+
+			got := v.String()
+			if b, ok := v.Interface().([]byte); ok {
+				got = string(b)
+			}
+			if got != payload {
+				t.Fatalf("%s: got value %q, want %q", name, got, payload)
+			}
+		}
+
+		// only one field of the oneof is set, the target one if any
+		oneof := req.WhichOneof(method.input.Oneofs().ByName("choice"))
+		wantOneof := "first"
+		if name == "second" {
+			wantOneof = "second"
+		}
+		if oneof == nil || string(oneof.Name()) != wantOneof {
+			t.Fatalf("%s: got oneof field %v, want %s", name, oneof, wantOneof)
+		}
+
+		// other fields are filled with placeholder values
+		if id := req.Get(method.input.Fields().ByName("id")).Int(); id != 1 {
+			t.Fatalf("%s: got id %d, want 1", name, id)
+		}
+		if name != "name" && req.Get(method.input.Fields().ByName("name")).String() != grpcPlaceholderString {
+			t.Fatalf("%s: name isn't filled", name)
+		}
+	}
+}
+
+func TestFillMessageRecursive(t *testing.T) {
+	method := testGRPCMethods(t)["/test.v1.Test/Unary"]
+	target := &grpcTarget{placeholder: "gRPC", method: method}
+
+	req := target.newRequest("")
+
+	depth := 0
+	msg := req.Get(method.input.Fields().ByName("node")).Message()
+	for msg.IsValid() {
+		depth++
+		msg = msg.Get(msg.Descriptor().Fields().ByName("child")).Message()
+	}
+
+	if depth == 0 || depth >= maxGRPCFieldDepth {
+		t.Fatalf("got recursive message depth %d, want from 1 to %d", depth, maxGRPCFieldDepth-1)
+	}
+}
+
+func TestGRPCTargets(t *testing.T) {
+	methods := testGRPCMethods(t)
+
+	var list []*grpcMethod
+	for _, name := range []string{"Unary", "ServerStream", "ClientStream", "BidiStream"} {
+		list = append(list, methods["/test.v1.Test/"+name])
+	}
+
+	fields := len(payloadFields(list[0].input))
+
+	tests := []struct {
+		placeholder string
+		methods     []string
+		withFields  bool
+	}{
+		{placeholder.DefaultGRPC.GetName(), []string{"Unary", "ServerStream"}, true},
+		{placeholder.DefaultGRPCMetadata.GetName(), []string{"Unary", "ServerStream"}, false},
+		{placeholder.DefaultGRPCBinaryMetadata.GetName(), []string{"Unary", "ServerStream"}, false},
+		{placeholder.DefaultGRPCClientStream.GetName(), []string{"ClientStream"}, true},
+		{placeholder.DefaultGRPCBidiStream.GetName(), []string{"BidiStream"}, true},
+		{placeholder.DefaultURLParam.GetName(), nil, false},
+	}
+
+	for _, tt := range tests {
+		targets := grpcTargets(tt.placeholder, list)
+
+		count := make(map[string]int)
+		for _, target := range targets {
+			if target.placeholder != tt.placeholder {
+				t.Fatalf("%s: got target of %s", tt.placeholder, target.placeholder)
+			}
+			if tt.withFields != (len(target.field) != 0) {
+				t.Fatalf("%s: unexpected target %s", tt.placeholder, target)
+			}
+			count[strings.TrimPrefix(target.method.fullMethod, "/test.v1.Test/")]++
+		}
+
+		if len(count) != len(tt.methods) {
+			t.Fatalf("%s: got methods %v, want %v", tt.placeholder, count, tt.methods)
+		}
+
+		want := 1
+		if tt.withFields {
+			want = fields
+		}
+		for _, name := range tt.methods {
+			if count[name] != want {
+				t.Fatalf("%s: got %d targets of %s, want %d", tt.placeholder, count[name], name, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	grpcPlaceholder "github.com/wallarm/gotestwaf/internal/payload/placeholder/grpc"
+)
+
+// ignoredGRPCServices contains infrastructure services which are not tested.
+var ignoredGRPCServices = map[string]interface{}{
+	"grpc.reflection.v1alpha.ServerReflection": nil,
+	"grpc.reflection.v1.ServerReflection":      nil,
+	"grpc.health.v1.Health":                    nil,
+}
+
+// grpcMethod contains information about the gRPC method.
+type grpcMethod struct {
+	// fullMethod is the method name in the "/package.Service/Method" format.
+	fullMethod      string
+	input           protoreflect.MessageDescriptor
+	output          protoreflect.MessageDescriptor
+	clientStreaming bool
+	serverStreaming bool
+}
+
+// serviceMethods returns all methods of the service.
+func serviceMethods(sd protoreflect.ServiceDescriptor) []*grpcMethod {
+	var methods []*grpcMethod
+
+	mds := sd.Methods()
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+
+		methods = append(methods, &grpcMethod{
+			fullMethod:      fmt.Sprintf("/%s/%s", sd.FullName(), md.Name()),
+			input:           md.Input(),
+			output:          md.Output(),
+			clientStreaming: md.IsStreamingClient(),
+			serverStreaming: md.IsStreamingServer(),
+		})
+	}
+
+	return methods
+}
+
+// defaultGRPCMethods returns methods of the built-in ServiceFooBar service.
+// They are used if the server doesn't support the reflection.
+func defaultGRPCMethods() []*grpcMethod {
+	return serviceMethods(grpcPlaceholder.File_service_proto.Services().ByName("ServiceFooBar"))
+}
+
+// reflectGRPCMethods uses the gRPC server reflection to get all methods
+// provided by the server.
+func reflectGRPCMethods(ctx context.Context, conn *grpc.ClientConn) ([]*grpcMethod, error) {
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't open reflection stream")
+	}
+	defer stream.CloseSend()
+
+	resp, err := reflectionRequest(stream, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't list services")
+	}
+
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		if _, ok := ignoredGRPCServices[service.GetName()]; ok {
+			continue
+		}
+		services = append(services, service.GetName())
+	}
+
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+
+	for _, service := range services {
+		resp, err = reflectionRequest(stream, &rpb.ServerReflectionRequest{
+			MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{
+				FileContainingSymbol: service,
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't get descriptor of %s", service)
+		}
+
+		err = addFileDescriptors(files, resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The server sends each file only once per stream, so some dependencies
+	// may be missing. Request them explicitly.
+	for {
+		missing := missingDependencies(files)
+		if len(missing) == 0 {
+			break
+		}
+
+		for _, fileName := range missing {
+			resp, err = reflectionRequest(stream, &rpb.ServerReflectionRequest{
+				MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{
+					FileByFilename: fileName,
+				},
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "couldn't get file %s", fileName)
+			}
+
+			err = addFileDescriptors(files, resp)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := files[fileName]; !ok {
+				return nil, fmt.Errorf("server didn't send file %s", fileName)
+			}
+		}
+	}
+
+	registry, err := newFileRegistry(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var methods []*grpcMethod
+
+	for _, service := range services {
+		d, err := registry.FindDescriptorByName(protoreflect.FullName(service))
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't find service %s", service)
+		}
+
+		sd, ok := d.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a service", service)
+		}
+
+		methods = append(methods, serviceMethods(sd)...)
+	}
+
+	return methods, nil
+}
+
+// reflectionRequest sends a request to the reflection stream and waits for
+// the response.
+func reflectionRequest(
+	stream rpb.ServerReflection_ServerReflectionInfoClient,
+	req *rpb.ServerReflectionRequest,
+) (*rpb.ServerReflectionResponse, error) {
+	err := stream.Send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, fmt.Errorf("reflection error: %s", errResp.GetErrorMessage())
+	}
+
+	return resp, nil
+}
+
+// addFileDescriptors decodes the file descriptors from the reflection response
+// and adds them to the files.
+func addFileDescriptors(
+	files map[string]*descriptorpb.FileDescriptorProto,
+	resp *rpb.ServerReflectionResponse,
+) error {
+	for _, rawFile := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		file := &descriptorpb.FileDescriptorProto{}
+
+		err := proto.Unmarshal(rawFile, file)
+		if err != nil {
+			return errors.Wrap(err, "couldn't decode file descriptor")
+		}
+
+		files[file.GetName()] = file
+	}
+
+	return nil
+}
+
+// missingDependencies returns names of all files that are imported but
+// not loaded yet.
+func missingDependencies(files map[string]*descriptorpb.FileDescriptorProto) []string {
+	var missing []string
+
+	for _, file := range files {
+		for _, dep := range file.GetDependency() {
+			if _, ok := files[dep]; !ok {
+				missing = append(missing, dep)
+			}
+		}
+	}
+
+	return missing
+}
+
+// newFileRegistry builds file descriptors from their protobuf representation.
+// All dependencies must be present in the files.
+func newFileRegistry(files map[string]*descriptorpb.FileDescriptorProto) (*protoregistry.Files, error) {
+	fileSet := &descriptorpb.FileDescriptorSet{}
+	for _, file := range files {
+		fileSet.File = append(fileSet.File, file)
+	}
+
+	registry, err := protodesc.NewFiles(fileSet)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't build file descriptors")
+	}
+
+	return registry, nil
+}
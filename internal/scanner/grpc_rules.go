@@ -0,0 +1,196 @@
+package scanner
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/wallarm/gotestwaf/internal/config"
+)
+
+// grpcResponse contains the result of the gRPC call.
+type grpcResponse struct {
+	// body is the JSON representation of the response message.
+	body string
+	// statusCode is the HTTP status code corresponding to the gRPC status.
+	statusCode int
+
+	status  *status.Status
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+// metadataRule matches the metadata values of the key against the regex.
+type metadataRule struct {
+	key   string
+	value *regexp.Regexp
+}
+
+// grpcRules contains gRPC-specific rules used to detect whether the call was
+// blocked or passed.
+type grpcRules struct {
+	blockCodes    map[codes.Code]interface{}
+	passCodes     map[codes.Code]interface{}
+	blockRegex    *regexp.Regexp
+	passRegex     *regexp.Regexp
+	blockMetadata []*metadataRule
+	blockTrailers []*metadataRule
+	passMetadata  []*metadataRule
+	passTrailers  []*metadataRule
+}
+
+func newGRPCRules(cfg *config.Config) (*grpcRules, error) {
+	var err error
+
+	r := &grpcRules{}
+
+	r.blockCodes, err = parseGRPCCodes(cfg.GRPCBlockCodes)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse gRPC block codes")
+	}
+
+	r.passCodes, err = parseGRPCCodes(cfg.GRPCPassCodes)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse gRPC pass codes")
+	}
+
+	if cfg.GRPCBlockRegex != "" {
+		r.blockRegex, err = regexp.Compile(cfg.GRPCBlockRegex)
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't compile gRPC block regex")
+		}
+	}
+
+	if cfg.GRPCPassRegex != "" {
+		r.passRegex, err = regexp.Compile(cfg.GRPCPassRegex)
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't compile gRPC pass regex")
+		}
+	}
+
+	r.blockMetadata, err = parseMetadataRules(cfg.GRPCBlockMetadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse gRPC block metadata rules")
+	}
+
+	r.blockTrailers, err = parseMetadataRules(cfg.GRPCBlockTrailers)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse gRPC block trailer rules")
+	}
+
+	r.passMetadata, err = parseMetadataRules(cfg.GRPCPassMetadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse gRPC pass metadata rules")
+	}
+
+	r.passTrailers, err = parseMetadataRules(cfg.GRPCPassTrailers)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse gRPC pass trailer rules")
+	}
+
+	return r, nil
+}
+
+// parseGRPCCodes parses gRPC status codes given by names (PERMISSION_DENIED)
+// or numbers (7).
+func parseGRPCCodes(rawCodes []string) (map[codes.Code]interface{}, error) {
+	grpcCodes := make(map[codes.Code]interface{})
+
+	for _, rawCode := range rawCodes {
+		rawCode = strings.ToUpper(strings.TrimSpace(rawCode))
+
+		jsonCode := rawCode
+		if strings.Trim(rawCode, "0123456789") != "" {
+			jsonCode = `"` + rawCode + `"`
+		}
+
+		var code codes.Code
+		if err := code.UnmarshalJSON([]byte(jsonCode)); err != nil {
+			return nil, fmt.Errorf("unknown gRPC status code: %s", rawCode)
+		}
+
+		grpcCodes[code] = nil
+	}
+
+	return grpcCodes, nil
+}
+
+// parseMetadataRules parses rules in the "key:regex" format.
+func parseMetadataRules(rawRules []string) ([]*metadataRule, error) {
+	var rules []*metadataRule
+
+	for _, rawRule := range rawRules {
+		kv := strings.SplitN(rawRule, ":", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("invalid rule %q, the key:regex format is expected", rawRule)
+		}
+
+		re, err := regexp.Compile(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't compile regex of the rule %q", rawRule)
+		}
+
+		rules = append(rules, &metadataRule{
+			key:   strings.ToLower(strings.TrimSpace(kv[0])),
+			value: re,
+		})
+	}
+
+	return rules, nil
+}
+
+// hasBlockRules returns true if any gRPC-specific block rule is set.
+func (r *grpcRules) hasBlockRules() bool {
+	return len(r.blockCodes) != 0 || r.blockRegex != nil ||
+		len(r.blockMetadata) != 0 || len(r.blockTrailers) != 0
+}
+
+// hasPassRules returns true if any gRPC-specific pass rule is set.
+func (r *grpcRules) hasPassRules() bool {
+	return len(r.passCodes) != 0 || r.passRegex != nil ||
+		len(r.passMetadata) != 0 || len(r.passTrailers) != 0
+}
+
+// isBlocked returns true if the response matches any of the block rules.
+func (r *grpcRules) isBlocked(resp *grpcResponse) bool {
+	if _, ok := r.blockCodes[resp.status.Code()]; ok {
+		return true
+	}
+
+	if r.blockRegex != nil && r.blockRegex.MatchString(resp.status.Message()) {
+		return true
+	}
+
+	return matchMetadata(r.blockMetadata, resp.header) || matchMetadata(r.blockTrailers, resp.trailer)
+}
+
+// isPassed returns true if the response matches any of the pass rules.
+func (r *grpcRules) isPassed(resp *grpcResponse) bool {
+	if _, ok := r.passCodes[resp.status.Code()]; ok {
+		return true
+	}
+
+	if r.passRegex != nil && r.passRegex.MatchString(resp.status.Message()) {
+		return true
+	}
+
+	return matchMetadata(r.passMetadata, resp.header) || matchMetadata(r.passTrailers, resp.trailer)
+}
+
+// matchMetadata returns true if any value of the metadata matches any rule.
+func matchMetadata(rules []*metadataRule, md metadata.MD) bool {
+	for _, rule := range rules {
+		for _, value := range md.Get(rule.key) {
+			if rule.value.MatchString(value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
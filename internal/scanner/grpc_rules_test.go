@@ -0,0 +1,157 @@
+package scanner
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/wallarm/gotestwaf/internal/config"
+)
+
+func TestParseGRPCCodes(t *testing.T) {
+	tests := []struct {
+		raw   []string
+		codes []codes.Code
+		ok    bool
+	}{
+		{nil, nil, true},
+		{[]string{"PERMISSION_DENIED"}, []codes.Code{codes.PermissionDenied}, true},
+		{[]string{" permission_denied "}, []codes.Code{codes.PermissionDenied}, true},
+		{[]string{"7", "OK", "5"}, []codes.Code{codes.PermissionDenied, codes.OK, codes.NotFound}, true},
+		{[]string{"PERMISSION_DENIED", "7"}, []codes.Code{codes.PermissionDenied}, true},
+		{[]string{"DENIED"}, nil, false},
+		{[]string{"100"}, nil, false},
+		{[]string{""}, nil, false},
+	}
+
+	for _, tt := range tests {
+		got, err := parseGRPCCodes(tt.raw)
+		if (err == nil) != tt.ok {
+			t.Fatalf("%q: got error %v", tt.raw, err)
+		}
+		if !tt.ok {
+			continue
+		}
+
+		if len(got) != len(tt.codes) {
+			t.Fatalf("%q: got codes %v, want %v", tt.raw, got, tt.codes)
+		}
+		for _, code := range tt.codes {
+			if _, ok := got[code]; !ok {
+				t.Fatalf("%q: code %s not found in %v", tt.raw, code, got)
+			}
+		}
+	}
+}
+
+func TestParseMetadataRules(t *testing.T) {
+	tests := []struct {
+		raw   string
+		key   string
+		regex string
+		ok    bool
+	}{
+		{"x-waf-action:block", "x-waf-action", "block", true},
+		{" X-WAF-Action : ^block$ ", "x-waf-action", "^block$", true},
+		{"x-waf-id:", "x-waf-id", "", true},
+		{"x-waf:a:b", "x-waf", "a:b", true},
+		{"x-waf-action", "", "", false},
+		{":block", "", "", false},
+		{"x-waf-action:[", "", "", false},
+	}
+
+	for _, tt := range tests {
+		rules, err := parseMetadataRules([]string{tt.raw})
+		if (err == nil) != tt.ok {
+			t.Fatalf("%q: got error %v", tt.raw, err)
+		}
+		if !tt.ok {
+			continue
+		}
+
+		if len(rules) != 1 || rules[0].key != tt.key || rules[0].value.String() != tt.regex {
+			t.Fatalf("%q: got rules %+v, want key %q and regex %q", tt.raw, rules, tt.key, tt.regex)
+		}
+	}
+}
+
+func TestGRPCRules(t *testing.T) {
+	cfg := &config.Config{
+		GRPCBlockCodes:    []string{"PERMISSION_DENIED"},
+		GRPCPassCodes:     []string{"OK"},
+		GRPCBlockRegex:    "blocked by WAF",
+		GRPCPassRegex:     "^not found$",
+		GRPCBlockMetadata: []string{"x-waf-action:block"},
+		GRPCBlockTrailers: []string{"x-waf-status:^denied"},
+		GRPCPassMetadata:  []string{"x-waf-action:allow"},
+		GRPCPassTrailers:  []string{"x-waf-status:^accepted"},
+	}
+
+	rules, err := newGRPCRules(cfg)
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	if !rules.hasBlockRules() || !rules.hasPassRules() {
+		t.Fatalf("rules aren't set")
+	}
+
+	tests := []struct {
+		name    string
+		code    codes.Code
+		message string
+		header  metadata.MD
+		trailer metadata.MD
+		blocked bool
+		passed  bool
+	}{
+		{"block code", codes.PermissionDenied, "", nil, nil, true, false},
+		{"pass code", codes.OK, "", nil, nil, false, true},
+		{"block regex", codes.Unavailable, "request blocked by WAF", nil, nil, true, false},
+		{"pass regex", codes.NotFound, "not found", nil, nil, false, true},
+		{"pass regex mismatch", codes.NotFound, "user not found", nil, nil, false, false},
+		{"block metadata", codes.Internal, "", metadata.Pairs("x-waf-action", "block"), nil, true, false},
+		{"block trailer", codes.Internal, "", nil, metadata.Pairs("x-waf-status", "denied: sqli"), true, false},
+		{"block metadata in trailer", codes.Internal, "", nil, metadata.Pairs("x-waf-action", "block"), false, false},
+		{"pass metadata", codes.Internal, "", metadata.Pairs("x-waf-action", "allow"), nil, false, true},
+		{"pass trailer", codes.Internal, "", nil, metadata.Pairs("x-waf-status", "accepted"), false, true},
+		{"pass trailer mismatch", codes.Internal, "", nil, metadata.Pairs("x-waf-status", "not accepted"), false, false},
+		{"multiple values", codes.Internal, "", metadata.Pairs("x-waf-action", "log", "x-waf-action", "block"), nil, true, false},
+		{"no match", codes.Unknown, "error", metadata.Pairs("x-other", "block"), nil, false, false},
+	}
+
+	for _, tt := range tests {
+		resp := &grpcResponse{
+			status:  status.New(tt.code, tt.message),
+			header:  tt.header,
+			trailer: tt.trailer,
+		}
+
+		if got := rules.isBlocked(resp); got != tt.blocked {
+			t.Fatalf("%s: got blocked %v, want %v", tt.name, got, tt.blocked)
+		}
+		if got := rules.isPassed(resp); got != tt.passed {
+			t.Fatalf("%s: got passed %v, want %v", tt.name, got, tt.passed)
+		}
+	}
+
+	for _, cfg := range []*config.Config{
+		{GRPCPassMetadata: []string{"x-waf-action"}},
+		{GRPCPassTrailers: []string{"x-waf-status:("}},
+		{GRPCBlockCodes: []string{"UNKNOWN_CODE"}},
+	} {
+		if _, err = newGRPCRules(cfg); err == nil {
+			t.Fatalf("invalid rules are accepted: %+v", cfg)
+		}
+	}
+
+	rules, err = newGRPCRules(&config.Config{GRPCPassTrailers: []string{"x-waf-status:ok"}})
+	if err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+	if rules.hasBlockRules() || !rules.hasPassRules() {
+		t.Fatalf("pass trailer rules aren't considered as pass rules")
+	}
+}
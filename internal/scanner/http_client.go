@@ -59,7 +59,7 @@ func NewHTTPClient(cfg *config.Config) (*HTTPClient, error) {
 	}
 
 	client := &http.Client{
-		Transport:     tr,
+		Transport:     &chunkedTransport{Transport: tr},
 		CheckRedirect: redirectFunc,
 	}
 
@@ -106,10 +106,11 @@ func (c *HTTPClient) SendPayload(
 
 	req = req.WithContext(ctx)
 
-	for header, value := range c.headers {
-		req.Header.Set(header, value)
+	c.setHeaders(req)
+	// user-defined placeholders can set the Host header
+	if c.hostHeader != "" {
+		req.Host = c.hostHeader
 	}
-	req.Host = c.hostHeader
 
 	if testHeaderValue != "" {
 		req.Header.Set(GTWDebugHeader, testHeaderValue)
@@ -138,6 +139,18 @@ func (c *HTTPClient) SendPayload(
 	}
 	statusCode = resp.StatusCode
 
+	// gRPC-Web and Connect responses carry their own status
+	if decoder, ok := placeholder.Get(placeholderName).(placeholder.ResponseDecoder); ok {
+		st, err := decoder.DecodeResponse(statusCode, resp.Header, bodyBytes)
+		if err != nil {
+			return "", 0, errors.Wrap(err, "decoding response")
+		}
+
+		if st != nil {
+			statusCode = grpcCodeToHTTPStatus(st.Code())
+		}
+	}
+
 	if c.followCookies && !c.renewSession && c.client.Jar != nil {
 		c.client.Jar.SetCookies(req.URL, resp.Cookies())
 	}
@@ -145,16 +158,30 @@ func (c *HTTPClient) SendPayload(
 	return string(bodyBytes), statusCode, nil
 }
 
+// setHeaders sets the user-defined headers. The user-defined cookies are
+// added to the Cookie header of the request, so they don't overwrite the
+// cookies set by the placeholder.
+func (c *HTTPClient) setHeaders(req *http.Request) {
+	for header, value := range c.headers {
+		if http.CanonicalHeaderKey(header) == "Cookie" && req.Header.Get("Cookie") != "" {
+			req.Header.Set("Cookie", req.Header.Get("Cookie")+"; "+value)
+			continue
+		}
+		req.Header.Set(header, value)
+	}
+}
+
 func (c *HTTPClient) SendRequest(req *http.Request, testHeaderValue string) (
 	respHeaders http.Header,
 	body string,
 	statusCode int,
 	err error,
 ) {
-	for header, value := range c.headers {
-		req.Header.Set(header, value)
+	c.setHeaders(req)
+	// the Host header can be set by the placeholder or the request template
+	if c.hostHeader != "" {
+		req.Host = c.hostHeader
 	}
-	req.Host = c.hostHeader
 
 	if testHeaderValue != "" {
 		req.Header.Set(GTWDebugHeader, testHeaderValue)
@@ -191,7 +218,7 @@ func (c *HTTPClient) SendRequest(req *http.Request, testHeaderValue string) (
 }
 
 func (c *HTTPClient) getCookies(ctx context.Context, targetURL string) ([]*http.Cookie, error) {
-	tr, ok := c.client.Transport.(*http.Transport)
+	tr, ok := c.client.Transport.(*chunkedTransport)
 	if !ok {
 		return nil, errors.New("couldn't copy transport settings of the main HTTP to get cookies")
 	}
@@ -0,0 +1,278 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/wallarm/gotestwaf/internal/db"
+	"github.com/wallarm/gotestwaf/internal/payload/encoder"
+	"github.com/wallarm/gotestwaf/internal/payload/placeholder"
+)
+
+const (
+	// limitPaddingChar is the padding inserted before the payload, the same
+	// as in the {{pad n}} payload templates.
+	limitPaddingChar = "A"
+
+	// limitPaddingSeparator separates the padding and the payload.
+	limitPaddingSeparator = ";"
+
+	// limitPaddingHeader is the name prefix of the headers sent before the
+	// header with the payload. Headers are written in the sorted order, so
+	// they go before the X-<hex> header of the Header placeholder.
+	limitPaddingHeader = "A-Pad-"
+)
+
+// limitProbe is the search of the inspection limit in one location.
+type limitProbe struct {
+	test *db.Info
+	unit string
+	max  int
+}
+
+// ProbeLimits finds the inspection limits of the WAF. For each placeholder
+// with a blocked true-positive payload, the padding before the payload is
+// searched with the binary search: the smallest padding which makes the WAF
+// pass the payload is the number of bytes the WAF inspects in the location.
+// For the Header placeholders, the number of headers sent before the header
+// with the payload is searched as well.
+func (s *Scanner) ProbeLimits(ctx context.Context) error {
+	if s.requestTemplates != nil {
+		s.logger.Info("Inspection limit probe is not supported with the OpenAPI file, skipping")
+		return nil
+	}
+
+	probes := s.limitProbes()
+
+	s.logger.WithFields(logrus.Fields{
+		"locations":   len(probes),
+		"max_size":    s.cfg.ProbeMaxSize,
+		"max_headers": s.cfg.ProbeMaxHeaders,
+	}).Info("Inspection limit probe started")
+
+	start := time.Now()
+	defer func() {
+		s.logger.WithField("duration", time.Since(start).String()).Info("Inspection limit probe finished")
+	}()
+
+	probeChan := make(chan *limitProbe)
+
+	go func() {
+		defer close(probeChan)
+		for _, p := range probes {
+			select {
+			case probeChan <- p:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(s.cfg.Workers)
+
+	for e := 0; e < s.cfg.Workers; e++ {
+		go func(ctx context.Context) {
+			defer wg.Done()
+			for {
+				select {
+				case p, ok := <-probeChan:
+					if !ok {
+						return
+					}
+
+					s.probeLimit(ctx, p)
+
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(ctx)
+	}
+
+	wg.Wait()
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// limitProbes returns the probes of the placeholders with blocked
+// true-positive payloads. One payload is used per placeholder, the first one
+// in the order of the test sets, the test cases and the payloads. Only the
+// payloads sent with the Plain encoder are used, so the encoder doesn't
+// change the size of the padding.
+func (s *Scanner) limitProbes() []*limitProbe {
+	tests := s.db.GetBlockedTruePositiveTests()
+	sort.Slice(tests, func(i, j int) bool {
+		a, b := tests[i], tests[j]
+		if a.Set != b.Set {
+			return a.Set < b.Set
+		}
+		if a.Case != b.Case {
+			return a.Case < b.Case
+		}
+		return a.Payload < b.Payload
+	})
+
+	var probes []*limitProbe
+	seen := make(map[string]bool)
+
+	for _, t := range tests {
+		if t.Encoder != encoder.DefaultPlainEncoder.GetName() || seen[t.Placeholder] {
+			continue
+		}
+		seen[t.Placeholder] = true
+
+		ph := placeholder.Get(t.Placeholder)
+		if _, ok := ph.(placeholder.GRPC); ok {
+			continue
+		}
+
+		probes = append(probes, &limitProbe{test: t, unit: db.LimitUnitBytes, max: s.cfg.ProbeMaxSize})
+
+		if _, ok := ph.(placeholder.Header); ok {
+			probes = append(probes, &limitProbe{test: t, unit: db.LimitUnitHeaders, max: s.cfg.ProbeMaxHeaders})
+		}
+	}
+
+	sort.SliceStable(probes, func(i, j int) bool {
+		return probes[i].test.Placeholder < probes[j].test.Placeholder
+	})
+
+	return probes
+}
+
+// probeLimit searches the smallest padding which makes the WAF pass the
+// payload and saves the result. The payload must be blocked without padding.
+// It's expected that the payload is blocked with any padding smaller than
+// the limit.
+func (s *Scanner) probeLimit(ctx context.Context, p *limitProbe) {
+	t := p.test
+	limit := &db.InspectionLimit{
+		Payload:     t.Payload,
+		Encoder:     t.Encoder,
+		Placeholder: t.Placeholder,
+		Set:         t.Set,
+		Case:        t.Case,
+		Unit:        p.unit,
+		MaxPadding:  p.max,
+	}
+
+	bypassed := func(padding int) bool {
+		s.delay()
+		limit.Requests++
+
+		ok, err := s.sendPadded(ctx, p, padding)
+		if err != nil {
+			s.logger.WithError(err).WithFields(logrus.Fields{
+				"placeholder": t.Placeholder,
+				"padding":     fmt.Sprintf("%d %s", padding, p.unit),
+			}).Debug("send padded payload failed")
+		}
+
+		return ok
+	}
+
+	switch {
+	case bypassed(0):
+		limit.Result = db.LimitNotBlocked
+
+	case !bypassed(p.max):
+		limit.Result = db.LimitNotReached
+
+	default:
+		// the payload is blocked with the lo padding and passed with the hi
+		// padding
+		lo, hi := 0, p.max
+		for hi-lo > 1 {
+			if ctx.Err() != nil {
+				return
+			}
+
+			mid := lo + (hi-lo)/2
+			if bypassed(mid) {
+				hi = mid
+			} else {
+				lo = mid
+			}
+		}
+
+		limit.Result = db.LimitFound
+		limit.Limit = hi
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.db.UpdateInspectionLimits(limit)
+}
+
+// sendPadded sends the payload with the padding and returns true if the WAF
+// has passed it. The requests which fail or which are neither blocked nor
+// passed, e.g. rejected as too large, aren't considered passed.
+func (s *Scanner) sendPadded(ctx context.Context, p *limitProbe, padding int) (bool, error) {
+	var (
+		body       string
+		statusCode int
+		err        error
+	)
+
+	t := p.test
+
+	if p.unit == db.LimitUnitHeaders {
+		body, statusCode, err = s.sendPaddedHeaders(ctx, t, padding)
+	} else {
+		payload := t.Payload
+		if padding > 0 {
+			payload = strings.Repeat(limitPaddingChar, padding) + limitPaddingSeparator + payload
+		}
+		body, statusCode, err = s.httpClient.SendPayload(ctx, s.cfg.URL, t.Placeholder, t.Encoder, payload, "")
+	}
+	if err != nil {
+		return false, err
+	}
+
+	blocked, err := s.checkBlocking(body, statusCode)
+	if err != nil {
+		return false, err
+	}
+
+	passed, err := s.checkPass(body, statusCode)
+	if err != nil {
+		return false, err
+	}
+
+	return passed && !blocked, nil
+}
+
+// sendPaddedHeaders sends the payload in the header placeholder after the
+// number of harmless headers.
+func (s *Scanner) sendPaddedHeaders(ctx context.Context, t *db.Info, headers int) (body string, statusCode int, err error) {
+	encodedPayload, err := encoder.Apply(t.Encoder, t.Payload)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "encoding payload")
+	}
+
+	req, err := placeholder.Apply(s.cfg.URL, t.Placeholder, encodedPayload)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "apply placeholder")
+	}
+
+	for i := 0; i < headers; i++ {
+		req.Header.Set(fmt.Sprintf("%s%06d", limitPaddingHeader, i), limitPaddingChar)
+	}
+
+	_, body, statusCode, err = s.httpClient.SendRequest(req.WithContext(ctx), "")
+
+	return body, statusCode, err
+}
@@ -0,0 +1,196 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wallarm/gotestwaf/internal/db"
+)
+
+// limitWAF is the HTTP handler which blocks the payload in the URL
+// parameters, the headers and the Host header. The WAF inspects only the
+// first bytes of the value and the first headers of the request: the payload
+// passes if it follows at least bytes padding characters, or if at least
+// headers padding headers are sent.
+type limitWAF struct {
+	payload string
+	bytes   int
+	headers int
+}
+
+func (w *limitWAF) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	values := []string{r.Host}
+	paddingHeaders := 0
+
+	for name, v := range r.Header {
+		if strings.HasPrefix(name, limitPaddingHeader) {
+			paddingHeaders++
+		}
+		values = append(values, v...)
+	}
+	// the query is split on & only, url.Values drops the parameters with the
+	// padding separator
+	for _, param := range strings.Split(r.URL.RawQuery, "&") {
+		if i := strings.Index(param, "="); i != -1 {
+			values = append(values, param[i+1:])
+		}
+	}
+
+	for _, v := range values {
+		if !strings.Contains(v, w.payload) {
+			continue
+		}
+
+		padding := len(v) - len(strings.TrimLeft(v, limitPaddingChar))
+		if padding < w.bytes && paddingHeaders < w.headers {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+	}
+
+	rw.WriteHeader(http.StatusOK)
+}
+
+func findInspectionLimit(s *Scanner, placeholder, unit string) *db.InspectionLimit {
+	for _, l := range s.db.GetStatistics(false, false).InspectionLimits {
+		if l.Placeholder == placeholder && l.Unit == unit {
+			return l
+		}
+	}
+
+	return nil
+}
+
+func TestProbeLimit(t *testing.T) {
+	const (
+		maxSize    = 1024
+		maxHeaders = 100
+	)
+
+	tests := []struct {
+		name        string
+		placeholder string
+		payload     string
+		unit        string
+		wafBytes    int
+		wafHeaders  int
+		result      string
+		limit       int
+	}{
+		{"URL parameter", "URLParam(name=q)", "<script>", db.LimitUnitBytes, 100, 1000, db.LimitFound, 100},
+		{"header", "Header(name=X-Payload)", "<script>", db.LimitUnitBytes, 1, 1000, db.LimitFound, 1},
+		{"header count", "Header(name=X-Payload)", "<script>", db.LimitUnitHeaders, 10000, 20, db.LimitFound, 20},
+		{"Host header count", "Header(name=Host)", "attack", db.LimitUnitHeaders, 10000, 30, db.LimitFound, 30},
+		{"body isn't inspected", "HTMLForm(name=q)", "<script>", db.LimitUnitBytes, 100, 1000, db.LimitNotBlocked, 0},
+		{"bytes limit is too large", "URLParam(name=q)", "<script>", db.LimitUnitBytes, 10000, 1000, db.LimitNotReached, 0},
+		{"headers limit is too large", "Header(name=X-Payload)", "<script>", db.LimitUnitHeaders, 10000, 1000, db.LimitNotReached, 0},
+	}
+
+	for _, tt := range tests {
+		srv := httptest.NewServer(&limitWAF{payload: tt.payload, bytes: tt.wafBytes, headers: tt.wafHeaders})
+
+		s := newTestScanner(t, srv.URL)
+
+		info := testBlockedInfo(tt.placeholder)
+		info.Payload = tt.payload
+
+		max := maxSize
+		if tt.unit == db.LimitUnitHeaders {
+			max = maxHeaders
+		}
+
+		s.probeLimit(context.Background(), &limitProbe{test: info, unit: tt.unit, max: max})
+		srv.Close()
+
+		l := findInspectionLimit(s, tt.placeholder, tt.unit)
+		if l == nil {
+			t.Fatalf("%s: inspection limit isn't saved", tt.name)
+		}
+		if l.Result != tt.result || l.Limit != tt.limit {
+			t.Fatalf("%s: got result %q with limit %d, want %q with limit %d", tt.name, l.Result, l.Limit, tt.result, tt.limit)
+		}
+		if l.MaxPadding != max || l.Requests == 0 {
+			t.Fatalf("%s: got max padding %d and %d requests", tt.name, l.MaxPadding, l.Requests)
+		}
+	}
+}
+
+func TestLimitProbes(t *testing.T) {
+	s := newTestScanner(t, "http://localhost")
+
+	for _, info := range []*db.Info{
+		{Payload: "b", Encoder: "Plain", Placeholder: "URLParam", Set: "set", Case: "case"},
+		{Payload: "a", Encoder: "URL", Placeholder: "URLParam", Set: "set", Case: "case"},
+		{Payload: "a", Encoder: "Plain", Placeholder: "URLParam", Set: "set", Case: "case"},
+		{Payload: "a", Encoder: "Plain", Placeholder: "Header(name=X-Payload)", Set: "set", Case: "case"},
+		{Payload: "a", Encoder: "Plain", Placeholder: "Header(name=X-Payload)", Set: "set", Case: "case"},
+		{Payload: "a", Encoder: "Plain", Placeholder: "gRPC", Set: "set", Case: "case"},
+		{Payload: "a", Encoder: "URL", Placeholder: "Header(name=X-Encoded)", Set: "set", Case: "case"},
+	} {
+		s.db.UpdateBlockedTests(info)
+	}
+
+	var got []string
+	for _, p := range s.limitProbes() {
+		got = append(got, strings.Join([]string{p.test.Placeholder, p.unit, p.test.Payload, p.test.Encoder}, " "))
+
+		max := s.cfg.ProbeMaxSize
+		if p.unit == db.LimitUnitHeaders {
+			max = s.cfg.ProbeMaxHeaders
+		}
+		if p.max != max {
+			t.Fatalf("%s: got max padding %d, want %d", p.test.Placeholder, p.max, max)
+		}
+	}
+
+	want := []string{
+		"Header(name=X-Payload) bytes a Plain",
+		"Header(name=X-Payload) headers a Plain",
+		"URLParam bytes a Plain",
+	}
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got probes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestProbeLimits(t *testing.T) {
+	srv := httptest.NewServer(&limitWAF{payload: "<script>", bytes: 50, headers: 10})
+	defer srv.Close()
+
+	s := newTestScanner(t, srv.URL)
+
+	for _, placeholder := range []string{"URLParam(name=q)", "Header(name=X-Payload)"} {
+		info := testBlockedInfo(placeholder)
+		info.Payload = "<script>"
+		s.db.UpdateBlockedTests(info)
+	}
+
+	if err := s.ProbeLimits(context.Background()); err != nil {
+		t.Fatalf("got an error while testing: %v", err)
+	}
+
+	tests := []struct {
+		placeholder string
+		unit        string
+		limit       int
+	}{
+		{"URLParam(name=q)", db.LimitUnitBytes, 50},
+		{"Header(name=X-Payload)", db.LimitUnitBytes, 50},
+		{"Header(name=X-Payload)", db.LimitUnitHeaders, 10},
+	}
+
+	if n := len(s.db.GetStatistics(false, false).InspectionLimits); n != len(tests) {
+		t.Fatalf("got %d inspection limits, want %d", n, len(tests))
+	}
+
+	for _, tt := range tests {
+		l := findInspectionLimit(s, tt.placeholder, tt.unit)
+		if l == nil || l.Result != db.LimitFound || l.Limit != tt.limit {
+			t.Fatalf("%s %s: got inspection limit %+v, want %d", tt.placeholder, tt.unit, l, tt.limit)
+		}
+	}
+}
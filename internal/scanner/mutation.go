@@ -0,0 +1,289 @@
+package scanner
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/wallarm/gotestwaf/internal/db"
+	"github.com/wallarm/gotestwaf/internal/payload/encoder"
+	"github.com/wallarm/gotestwaf/internal/payload/placeholder"
+)
+
+const (
+	// maxMutationChainLength is the maximum number of mutations applied to
+	// the blocked payload at once.
+	maxMutationChainLength = 3
+
+	// splitParamParts is the number of URL parameters the payload is split
+	// across by the SplitParam mutation.
+	splitParamParts = 2
+)
+
+// mutation is a single change of the blocked payload.
+type mutation struct {
+	name string
+
+	// encoder is applied to the payload. If beforeEncoder is true, it is
+	// applied to the raw payload before the test case encoder, otherwise it
+	// is applied to the result of the test case encoder.
+	encoder       string
+	beforeEncoder bool
+
+	// split is true if the encoded payload is split across several URL
+	// parameters with the same name.
+	split bool
+}
+
+// mutations are tried in the order they are listed.
+var mutations = []*mutation{
+	{name: "RandomCase", encoder: encoder.DefaultRandomCaseEncoder.GetName(), beforeEncoder: true},
+	{name: "SQLComment", encoder: encoder.DefaultSQLCommentEncoder.GetName(), beforeEncoder: true},
+	{name: "RandomWhitespace", encoder: encoder.DefaultRandomWhitespaceEncoder.GetName(), beforeEncoder: true},
+	{name: "URL", encoder: encoder.DefaultURLEncoder.GetName()},
+	{name: "DoubleURL", encoder: encoder.DefaultDoubleURLEncoder.GetName()},
+	{name: "IISUnicode", encoder: encoder.DefaultIISUnicodeEncoder.GetName()},
+	{name: "OverlongUTF8", encoder: encoder.DefaultOverlongUTF8Encoder.GetName()},
+	{name: "HTMLDecimal", encoder: encoder.DefaultHTMLDecimalEncoder.GetName()},
+	{name: "FullWidth", encoder: encoder.DefaultFullWidthEncoder.GetName()},
+	{name: "NullByte", encoder: encoder.DefaultNullByteEncoder.GetName()},
+	{name: "SplitParam", split: true},
+}
+
+// mutationChain is a combination of mutations applied to the blocked payload.
+type mutationChain []*mutation
+
+// names returns names of the mutations of the chain.
+func (c mutationChain) names() []string {
+	names := make([]string, 0, len(c))
+	for _, m := range c {
+		names = append(names, m.name)
+	}
+	return names
+}
+
+// encoder returns the encoder pipeline which applies the mutations of the
+// chain together with the test case encoder.
+func (c mutationChain) encoder(encoderName string) string {
+	var before, after []string
+
+	for _, m := range c {
+		if m.encoder == "" {
+			continue
+		}
+
+		if m.beforeEncoder {
+			before = append(before, m.encoder)
+		} else {
+			after = append(after, m.encoder)
+		}
+	}
+
+	names := append(before, encoderName)
+	names = append(names, after...)
+
+	return strings.Join(names, encoder.ChainSeparator)
+}
+
+// isSplit returns true if the chain contains the SplitParam mutation.
+func (c mutationChain) isSplit() bool {
+	for _, m := range c {
+		if m.split {
+			return true
+		}
+	}
+	return false
+}
+
+// mutationChains returns all combinations of up to maxLen mutations which
+// can be applied to the payload in the placeholder. Shorter chains go first,
+// so the first successful chain is the minimal one.
+func mutationChains(placeholderSpec string, maxLen int) []mutationChain {
+	_, isURLParam := placeholder.Get(placeholderSpec).(placeholder.URLParam)
+
+	var available []*mutation
+	for _, m := range mutations {
+		if m.split && !isURLParam {
+			continue
+		}
+		available = append(available, m)
+	}
+
+	var chains []mutationChain
+
+	var combine func(chain mutationChain, start, length int)
+	combine = func(chain mutationChain, start, length int) {
+		if len(chain) == length {
+			chains = append(chains, append(mutationChain(nil), chain...))
+			return
+		}
+
+		for i := start; i < len(available); i++ {
+			combine(append(chain, available[i]), i+1, length)
+		}
+	}
+
+	for length := 1; length <= maxLen; length++ {
+		combine(nil, 0, length)
+	}
+
+	return chains
+}
+
+// Mutate searches for mutations of the blocked true-positive payloads which
+// bypass the WAF. For each payload, mutation chains are tried from the
+// shortest to the longest until one of them passes or the request budget is
+// exhausted. The successful chains are saved as derived bypasses.
+func (s *Scanner) Mutate(ctx context.Context) error {
+	if s.requestTemplates != nil {
+		s.logger.Info("Payload mutation is not supported with the OpenAPI file, skipping")
+		return nil
+	}
+
+	var tests []*db.Info
+	for _, t := range s.db.GetBlockedTruePositiveTests() {
+		if _, ok := placeholder.Get(t.Placeholder).(placeholder.GRPC); ok {
+			continue
+		}
+		tests = append(tests, t)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"payloads": len(tests),
+		"budget":   s.cfg.MutationBudget,
+	}).Info("Payload mutation started")
+
+	start := time.Now()
+	defer func() {
+		s.logger.WithField("duration", time.Since(start).String()).Info("Payload mutation finished")
+	}()
+
+	testChan := make(chan *db.Info)
+
+	go func() {
+		defer close(testChan)
+		for _, t := range tests {
+			select {
+			case testChan <- t:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(s.cfg.Workers)
+
+	for e := 0; e < s.cfg.Workers; e++ {
+		go func(ctx context.Context) {
+			defer wg.Done()
+			for {
+				select {
+				case t, ok := <-testChan:
+					if !ok {
+						return
+					}
+
+					s.mutatePayload(ctx, t)
+
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(ctx)
+	}
+
+	wg.Wait()
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// mutatePayload tries mutation chains of the blocked payload within the
+// request budget and saves the first chain which has bypassed the WAF.
+func (s *Scanner) mutatePayload(ctx context.Context, t *db.Info) {
+	requests := 0
+
+	for _, chain := range mutationChains(t.Placeholder, maxMutationChainLength) {
+		if requests >= s.cfg.MutationBudget || ctx.Err() != nil {
+			return
+		}
+
+		s.delay()
+		requests++
+
+		mutatedPayload, body, statusCode, err := s.sendMutation(ctx, t, chain)
+		if err != nil {
+			s.logger.WithError(err).WithField("mutation", chain.names()).Debug("send mutated payload failed")
+			continue
+		}
+
+		blocked, err := s.checkBlocking(body, statusCode)
+		if err != nil {
+			continue
+		}
+
+		passed, err := s.checkPass(body, statusCode)
+		if err != nil {
+			continue
+		}
+
+		if passed && !blocked {
+			s.db.UpdateDerivedBypasses(&db.DerivedBypass{
+				Payload:            t.Payload,
+				Encoder:            t.Encoder,
+				Placeholder:        t.Placeholder,
+				Set:                t.Set,
+				Case:               t.Case,
+				Type:               t.Type,
+				Mutations:          chain.names(),
+				MutatedEncoder:     chain.encoder(t.Encoder),
+				MutatedPayload:     mutatedPayload,
+				Split:              chain.isSplit(),
+				ResponseStatusCode: statusCode,
+				Requests:           requests,
+			})
+
+			return
+		}
+	}
+}
+
+// sendMutation sends the blocked payload mutated by the chain. It returns
+// the mutated payload which was sent, because some mutations are random.
+func (s *Scanner) sendMutation(ctx context.Context, t *db.Info, chain mutationChain) (mutatedPayload, body string, statusCode int, err error) {
+	mutatedPayload, err = encoder.Apply(chain.encoder(t.Encoder), t.Payload)
+	if err != nil {
+		return "", "", 0, errors.Wrap(err, "encoding payload")
+	}
+
+	if !chain.isSplit() {
+		body, statusCode, err = s.httpClient.SendPayload(ctx, s.cfg.URL, t.Placeholder, encoder.DefaultPlainEncoder.GetName(), mutatedPayload, "")
+		return mutatedPayload, body, statusCode, err
+	}
+
+	ph, config, err := placeholder.Lookup(t.Placeholder)
+	if err != nil {
+		return "", "", 0, errors.Wrap(err, "apply placeholder")
+	}
+
+	urlParam, ok := ph.(placeholder.URLParam)
+	if !ok {
+		return "", "", 0, errors.Errorf("placeholder %s doesn't support splitting", t.Placeholder)
+	}
+
+	req, err := urlParam.CreateSplitRequest(s.cfg.URL, placeholder.SplitPayload(mutatedPayload, splitParamParts), config)
+	if err != nil {
+		return "", "", 0, errors.Wrap(err, "apply placeholder")
+	}
+
+	_, body, statusCode, err = s.httpClient.SendRequest(req.WithContext(ctx), "")
+
+	return mutatedPayload, body, statusCode, err
+}
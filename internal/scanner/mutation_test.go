@@ -0,0 +1,213 @@
+package scanner
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/wallarm/gotestwaf/internal/config"
+	"github.com/wallarm/gotestwaf/internal/db"
+)
+
+// newTestScanner creates the scanner which sends requests to the URL. The WAF
+// blocks requests with the 403 status code and passes them with 200.
+func newTestScanner(t *testing.T, url string) *Scanner {
+	cfg := &config.Config{
+		URL:              url,
+		HTTPHeaders:      map[string]string{},
+		MaxIdleConns:     2,
+		MaxRedirects:     50,
+		IdleConnTimeout:  2,
+		BlockStatusCodes: []int{403},
+		PassStatusCodes:  []int{200},
+		Workers:          2,
+		RandomDelay:      1,
+		MutationBudget:   30,
+		ProbeMaxSize:     1024,
+		ProbeMaxHeaders:  100,
+	}
+
+	testDB, err := db.NewDB([]*db.Case{{Set: "set", Name: "case", IsTruePositive: true}})
+	if err != nil {
+		t.Fatalf("couldn't create database: %v", err)
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	s, err := New(logger, cfg, testDB, nil, nil, false)
+	if err != nil {
+		t.Fatalf("couldn't create scanner: %v", err)
+	}
+
+	return s
+}
+
+func TestMutationChains(t *testing.T) {
+	tests := []struct {
+		placeholder string
+		split       bool
+	}{
+		{"Header", false},
+		{"URLPath", false},
+		{"URLParam", true},
+		{"URLParam(name=q)", true},
+	}
+
+	for _, tt := range tests {
+		n := len(mutations)
+		if !tt.split {
+			n--
+		}
+
+		chains := mutationChains(tt.placeholder, maxMutationChainLength)
+
+		if want := n + n*(n-1)/2 + n*(n-1)*(n-2)/6; len(chains) != want {
+			t.Fatalf("%s: got %d chains, want %d", tt.placeholder, len(chains), want)
+		}
+
+		for i, chain := range chains {
+			if i > 0 && len(chain) < len(chains[i-1]) {
+				t.Fatalf("%s: chain %v goes after the longer chain %v", tt.placeholder, chain.names(), chains[i-1].names())
+			}
+			if chain.isSplit() && !tt.split {
+				t.Fatalf("%s: got split chain %v", tt.placeholder, chain.names())
+			}
+		}
+
+		// single mutations go first in the order they are listed
+		for i := 0; i < n; i++ {
+			if len(chains[i]) != 1 || chains[i][0] != mutations[i] {
+				t.Fatalf("%s: got chain %v at %d, want %s", tt.placeholder, chains[i].names(), i, mutations[i].name)
+			}
+		}
+
+		if got := strings.Join(chains[n].names(), ","); got != "RandomCase,SQLComment" {
+			t.Fatalf("%s: got the first pair %s", tt.placeholder, got)
+		}
+	}
+
+	if chains := mutationChains("URLParam", 1); len(chains) != len(mutations) {
+		t.Fatalf("got %d chains of length 1, want %d", len(chains), len(mutations))
+	}
+}
+
+// testWAF is the HTTP handler which blocks requests unless pass returns true,
+// and saves the values of the q URL parameter.
+type testWAF struct {
+	mu       sync.Mutex
+	requests int
+	values   [][]string
+	pass     func(r *http.Request) bool
+}
+
+func (w *testWAF) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	w.mu.Lock()
+	w.requests++
+	w.values = append(w.values, r.URL.Query()["q"])
+	w.mu.Unlock()
+
+	if w.pass(r) {
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+
+	rw.WriteHeader(http.StatusForbidden)
+}
+
+func testBlockedInfo(placeholder string) *db.Info {
+	return &db.Info{
+		Payload:     "<script>alert(1)</script>",
+		Encoder:     "Plain",
+		Placeholder: placeholder,
+		Set:         "set",
+		Case:        "case",
+	}
+}
+
+func TestMutatePayloadBudget(t *testing.T) {
+	waf := &testWAF{pass: func(*http.Request) bool { return false }}
+
+	srv := httptest.NewServer(waf)
+	defer srv.Close()
+
+	s := newTestScanner(t, srv.URL)
+	s.cfg.MutationBudget = 5
+
+	s.mutatePayload(context.Background(), testBlockedInfo("URLParam(name=q)"))
+
+	if waf.requests != 5 {
+		t.Fatalf("got %d requests, want 5", waf.requests)
+	}
+	if stat := s.db.GetStatistics(false, false); len(stat.DerivedBypasses) != 0 {
+		t.Fatalf("got derived bypasses %v", stat.DerivedBypasses)
+	}
+}
+
+func TestMutatePayloadSplit(t *testing.T) {
+	// only the split payload bypasses the WAF
+	waf := &testWAF{pass: func(r *http.Request) bool { return len(r.URL.Query()["q"]) == 2 }}
+
+	srv := httptest.NewServer(waf)
+	defer srv.Close()
+
+	s := newTestScanner(t, srv.URL)
+
+	info := testBlockedInfo("URLParam(name=q)")
+	s.mutatePayload(context.Background(), info)
+
+	bypasses := s.db.GetStatistics(false, false).DerivedBypasses
+	if len(bypasses) != 1 {
+		t.Fatalf("got %d derived bypasses, want 1", len(bypasses))
+	}
+
+	b := bypasses[0]
+	if strings.Join(b.Mutations, ",") != "SplitParam" || !b.Split {
+		t.Fatalf("got mutations %v, split %v", b.Mutations, b.Split)
+	}
+	if b.Requests != len(mutations) || waf.requests != len(mutations) {
+		t.Fatalf("got %d requests, want %d", b.Requests, len(mutations))
+	}
+	if b.MutatedPayload != info.Payload {
+		t.Fatalf("got mutated payload %q, want %q", b.MutatedPayload, info.Payload)
+	}
+	if got := strings.Join(waf.values[len(waf.values)-1], ""); got != b.MutatedPayload {
+		t.Fatalf("got parts joined to %q, want %q", got, b.MutatedPayload)
+	}
+}
+
+func TestMutatePayloadRandom(t *testing.T) {
+	// the first mutation bypasses the WAF
+	waf := &testWAF{pass: func(*http.Request) bool { return true }}
+
+	srv := httptest.NewServer(waf)
+	defer srv.Close()
+
+	s := newTestScanner(t, srv.URL)
+
+	s.mutatePayload(context.Background(), testBlockedInfo("URLParam(name=q)"))
+
+	bypasses := s.db.GetStatistics(false, false).DerivedBypasses
+	if len(bypasses) != 1 {
+		t.Fatalf("got %d derived bypasses, want 1", len(bypasses))
+	}
+
+	b := bypasses[0]
+	if strings.Join(b.Mutations, ",") != "RandomCase" || b.Split || b.Requests != 1 {
+		t.Fatalf("got mutations %v, split %v, requests %d", b.Mutations, b.Split, b.Requests)
+	}
+
+	// the random payload which was sent is saved
+	if len(waf.values) != 1 || len(waf.values[0]) != 1 || waf.values[0][0] != b.MutatedPayload {
+		t.Fatalf("got sent values %v, want %q", waf.values, b.MutatedPayload)
+	}
+	if strings.ToLower(b.MutatedPayload) != "<script>alert(1)</script>" {
+		t.Fatalf("got mutated payload %q", b.MutatedPayload)
+	}
+}
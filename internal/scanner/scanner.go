@@ -53,6 +53,7 @@ type Scanner struct {
 
 	httpClient *HTTPClient
 	grpcConn   *GRPCConn
+	grpcRules  *grpcRules
 	wsClient   *websocket.Dialer
 
 	requestTemplates openapi.Templates
@@ -75,17 +76,23 @@ func New(
 		return nil, errors.Wrap(err, "couldn't create HTTP client")
 	}
 
-	grpcConn, err := NewGRPCConn(cfg)
+	grpcConn, err := NewGRPCConn(cfg, logger)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't create gRPC client")
 	}
 
+	grpcRules, err := newGRPCRules(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse gRPC rules")
+	}
+
 	return &Scanner{
 		logger:            logger,
 		cfg:               cfg,
 		db:                db,
 		httpClient:        httpClient,
 		grpcConn:          grpcConn,
+		grpcRules:         grpcRules,
 		requestTemplates:  requestTemplates,
 		router:            router,
 		wsClient:          websocket.DefaultDialer,
@@ -105,10 +112,22 @@ func (s *Scanner) CheckGRPCAvailability(ctx context.Context) {
 		}).WithError(err).Infof("gRPC pre-check")
 	}
 	if available {
-		s.logger.WithFields(logrus.Fields{
-			"status":     "done",
-			"connection": "available",
-		}).Info("gRPC pre-check")
+		targets, err := s.grpcConn.Targets(ctx, placeholder.DefaultGRPC.GetName())
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"status":     "done",
+				"connection": "not available",
+			}).WithError(err).Info("gRPC pre-check")
+
+			available = false
+		} else {
+			s.logger.WithFields(logrus.Fields{
+				"status":     "done",
+				"connection": "available",
+				"methods":    s.grpcConn.MethodsSource(),
+				"targets":    len(targets),
+			}).Info("gRPC pre-check")
+		}
 	} else {
 		s.logger.WithFields(logrus.Fields{
 			"status":     "done",
@@ -159,6 +178,55 @@ func (s *Scanner) WAFBlockCheck(ctx context.Context) error {
 	return nil
 }
 
+// GRPCBlockCheck checks if WAF blocks malicious gRPC calls. It should be called
+// after CheckGRPCAvailability.
+func (s *Scanner) GRPCBlockCheck(ctx context.Context) error {
+	if !s.grpcConn.IsAvailable() {
+		return nil
+	}
+
+	if s.cfg.SkipWAFBlockCheck {
+		s.logger.WithField("status", "skipped").Info("gRPC WAF pre-check")
+		return nil
+	}
+
+	targets, err := s.grpcConn.Targets(ctx, placeholder.DefaultGRPC.GetName())
+	if err != nil {
+		return errors.Wrap(err, "running gRPC WAF pre-check")
+	}
+
+	if len(targets) == 0 {
+		s.logger.WithField("status", "skipped").Info("gRPC WAF pre-check")
+		return nil
+	}
+
+	s.logger.WithField("target", targets[0].String()).Info("gRPC WAF pre-check")
+
+	resp, err := s.grpcConn.Send(ctx, targets[0], "Plain", preCheckVector)
+	if err != nil {
+		return errors.Wrap(err, "running gRPC WAF pre-check")
+	}
+
+	blocked, _, err := s.checkGRPCResponse(resp)
+	if err != nil {
+		return errors.Wrap(err, "running gRPC WAF pre-check")
+	}
+
+	if !blocked {
+		return errors.Errorf("WAF was not detected for gRPC calls. "+
+			"Please use the '--grpcBlockCodes', '--grpcBlockRegex', '--grpcBlockMetadata' or '--grpcBlockTrailers' flags. "+
+			"Use '--help' for additional info. Baseline attack status: %s", resp.status.Code())
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"status":  "done",
+		"blocked": true,
+		"code":    resp.status.Code(),
+	}).Info("gRPC WAF pre-check")
+
+	return nil
+}
+
 // preCheck sends given payload during the pre-check stage.
 func (s *Scanner) preCheck(ctx context.Context, payload string) (blocked bool, statusCode int, err error) {
 	body, code, err := s.httpClient.SendPayload(ctx, s.cfg.URL, "URLParam", "URL", payload, "")
@@ -303,7 +371,7 @@ func (s *Scanner) Run(ctx context.Context) error {
 					if !ok {
 						return
 					}
-					time.Sleep(time.Duration(s.cfg.SendDelay+rand.Intn(s.cfg.RandomDelay)) * time.Millisecond)
+					s.delay()
 
 					if err := s.scanURL(ctx, w); err != nil {
 						s.logger.WithError(err).Error("Got an error while scanning")
@@ -326,6 +394,17 @@ func (s *Scanner) Run(ctx context.Context) error {
 	return nil
 }
 
+// delay waits before sending the next request. The random delay is added
+// only if it's set.
+func (s *Scanner) delay() {
+	d := s.cfg.SendDelay
+	if s.cfg.RandomDelay > 0 {
+		d += rand.Intn(s.cfg.RandomDelay)
+	}
+
+	time.Sleep(time.Duration(d) * time.Millisecond)
+}
+
 // checkBlocking checks the response status-code or request body using
 // a regular expression to determine if the request has been blocked.
 func (s *Scanner) checkBlocking(body string, statusCode int) (bool, error) {
@@ -343,6 +422,31 @@ func (s *Scanner) checkBlocking(body string, statusCode int) (bool, error) {
 	return false, nil
 }
 
+// checkGRPCResponse checks the gRPC response using the gRPC-specific rules.
+// If there are no such rules, the HTTP rules are applied to the HTTP status
+// code corresponding to the gRPC status and to the response message.
+func (s *Scanner) checkGRPCResponse(resp *grpcResponse) (blocked, passed bool, err error) {
+	if s.grpcRules.hasBlockRules() {
+		blocked = s.grpcRules.isBlocked(resp)
+	} else {
+		blocked, err = s.checkBlocking(resp.body, resp.statusCode)
+		if err != nil {
+			return false, false, err
+		}
+	}
+
+	if s.grpcRules.hasPassRules() {
+		passed = s.grpcRules.isPassed(resp)
+	} else {
+		passed, err = s.checkPass(resp.body, resp.statusCode)
+		if err != nil {
+			return false, false, err
+		}
+	}
+
+	return blocked, passed, nil
+}
+
 // checkPass checks the response status-code or request body using
 // a regular expression to determine if the request has been passed.
 func (s *Scanner) checkPass(body string, statusCode int) (bool, error) {
@@ -426,7 +530,7 @@ func (s *Scanner) scanURL(ctx context.Context, w *testWork) error {
 		err         error
 	)
 
-	if w.placeholder == placeholder.DefaultGRPC.GetName() {
+	if _, ok := placeholder.Get(w.placeholder).(placeholder.GRPC); ok {
 		if !s.grpcConn.IsAvailable() {
 			return nil
 		}
@@ -436,19 +540,42 @@ func (s *Scanner) scanURL(ctx context.Context, w *testWork) error {
 			newCtx = metadata.AppendToOutgoingContext(ctx, GTWDebugHeader, w.debugHeaderValue)
 		}
 
-		body, statusCode, err = s.grpcConn.Send(newCtx, w.encoder, w.payload)
+		targets, err := s.grpcConn.Targets(ctx, w.placeholder)
+		if err != nil {
+			_, _, _, _, err = s.updateDB(ctx, w, nil, nil, nil, nil, nil,
+				0, nil, "", err, "", &grpcResponse{})
 
-		_, _, _, _, err = s.updateDB(ctx, w, nil, nil, nil, nil, nil,
-			statusCode, nil, body, err, "", true)
+			return err
+		}
 
-		return err
+		var passedTest *db.Info
+		var blockedTest *db.Info
+		var unresolvedTest *db.Info
+		var failedTest *db.Info
+
+		for _, target := range targets {
+			grpcResp, err := s.grpcConn.Send(newCtx, target, w.encoder, w.payload)
+			if grpcResp == nil {
+				grpcResp = &grpcResponse{}
+			}
+
+			passedTest, blockedTest, unresolvedTest, failedTest, err =
+				s.updateDB(ctx, w, passedTest, blockedTest, unresolvedTest, failedTest,
+					nil, grpcResp.statusCode, nil, grpcResp.body, err, target.String(), grpcResp)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
 	}
 
 	if s.requestTemplates == nil {
 		body, statusCode, err = s.httpClient.SendPayload(ctx, s.cfg.URL, w.placeholder, w.encoder, w.payload, w.debugHeaderValue)
 
 		_, _, _, _, err = s.updateDB(ctx, w, nil, nil, nil, nil, nil,
-			statusCode, nil, body, err, "", false)
+			statusCode, nil, body, err, "", nil)
 
 		return err
 	}
@@ -478,7 +605,7 @@ func (s *Scanner) scanURL(ctx context.Context, w *testWork) error {
 
 		passedTest, blockedTest, unresolvedTest, failedTest, err =
 			s.updateDB(ctx, w, passedTest, blockedTest, unresolvedTest, failedTest,
-				req, statusCode, respHeaders, body, err, additionalInfo, false)
+				req, statusCode, respHeaders, body, err, additionalInfo, nil)
 
 		s.db.AddToScannedPaths(template.Method, template.Path)
 
@@ -504,7 +631,7 @@ func (s *Scanner) updateDB(
 	respBody string,
 	sendErr error,
 	additionalInfo string,
-	isGRPC bool,
+	grpcResp *grpcResponse,
 ) (
 	updPassedTest *db.Info,
 	updBlockedTest *db.Info,
@@ -530,7 +657,7 @@ func (s *Scanner) updateDB(
 					s.db.UpdateNaTests(updUnresolvedTest, s.cfg.IgnoreUnresolved, s.cfg.NonBlockedAsPassed, w.isTruePositive)
 				}
 				if len(additionalInfo) != 0 {
-					unresolvedTest.AdditionalInfo = append(unresolvedTest.AdditionalInfo, additionalInfo)
+					updUnresolvedTest.AdditionalInfo = append(updUnresolvedTest.AdditionalInfo, additionalInfo)
 				}
 
 				return
@@ -553,6 +680,12 @@ func (s *Scanner) updateDB(
 	var blocked, passed bool
 	if blockedByReset {
 		blocked = true
+	} else if grpcResp != nil {
+		blocked, passed, err = s.checkGRPCResponse(grpcResp)
+		if err != nil {
+			return nil, nil, nil, nil,
+				errors.Wrap(err, "failed to check gRPC response")
+		}
 	} else {
 		blocked, err = s.checkBlocking(respBody, respStatusCode)
 		if err != nil {
@@ -567,7 +700,7 @@ func (s *Scanner) updateDB(
 		}
 	}
 
-	if s.requestTemplates != nil && !isGRPC {
+	if s.requestTemplates != nil && grpcResp == nil {
 		route, pathParams, routeErr := s.router.FindRoute(req)
 		if routeErr != nil {
 			// split Method and url template
@@ -629,7 +762,7 @@ func (s *Scanner) updateDB(
 			s.db.UpdateNaTests(updUnresolvedTest, s.cfg.IgnoreUnresolved, s.cfg.NonBlockedAsPassed, w.isTruePositive)
 		}
 		if len(additionalInfo) != 0 {
-			unresolvedTest.AdditionalInfo = append(unresolvedTest.AdditionalInfo, additionalInfo)
+			updUnresolvedTest.AdditionalInfo = append(updUnresolvedTest.AdditionalInfo, additionalInfo)
 		}
 	} else {
 		if blocked {
@@ -63,6 +63,9 @@ type HtmlReport struct {
 
 	ScannedPaths db.ScannedPaths `json:"scanned_paths" validate:"omitempty,max=2048,dive,required"`
 
+	DerivedBypasses []*DerivedBypass `json:"derived_bypasses" validate:"omitempty,dive,required"`
+	InspectionLimits []*InspectionLimit `json:"inspection_limits" validate:"omitempty,dive,required"`
+
 	NegativeTests struct {
 		SummaryTable map[string]*TestSetSummary `json:"summary_table" validate:"omitempty,dive,keys,required,max=256,endkeys,required"`
 
@@ -119,6 +122,26 @@ type TestDetails struct {
 	Placeholders map[string]any `json:"placeholders" validate:"required,placeholders"`
 }
 
+// DerivedBypass is the mutation chain which makes the blocked payload bypass
+// the WAF.
+type DerivedBypass struct {
+	Payload        string   `json:"payload" validate:"required,max=256000"`
+	MutatedPayload string   `json:"mutated_payload" validate:"required,max=256000"`
+	TestCase       string   `json:"test_case" validate:"required,printascii,max=256"`
+	Placeholder    string   `json:"placeholder" validate:"required,max=256"`
+	Mutations      []string `json:"mutations" validate:"required,max=16,dive,required,printascii,max=256"`
+	Requests       int      `json:"requests" validate:"min=0"`
+}
+
+// InspectionLimit is the amount of the request location inspected by the WAF.
+type InspectionLimit struct {
+	Placeholder string `json:"placeholder" validate:"required,max=256"`
+	Limit       string `json:"limit" validate:"required,printascii,max=64"`
+	Payload     string `json:"payload" validate:"required,max=256000"`
+	TestCase    string `json:"test_case" validate:"required,printascii,max=256"`
+	Requests    int    `json:"requests" validate:"min=0"`
+}
+
 type TestSetSummary struct {
 	TestCases []*db.SummaryTableRow `json:"test_cases" validate:"required,max=1024,dive,required"`
 
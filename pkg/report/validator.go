@@ -73,7 +73,7 @@ func validateEncoders(fl validator.FieldLevel) bool {
 	}
 
 	for _, e := range encoders {
-		if _, ok := encoder.Encoders[e.String()]; !ok {
+		if _, err := encoder.ParseChain(e.String()); err != nil {
 			return false
 		}
 	}
@@ -93,7 +93,7 @@ func validatePlaceholders(fl validator.FieldLevel) bool {
 	}
 
 	for _, p := range placeholders {
-		if _, ok := placeholder.Placeholders[p.String()]; !ok {
+		if placeholder.Get(p.String()) == nil {
 			return false
 		}
 	}
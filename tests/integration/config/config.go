@@ -22,6 +22,12 @@ var (
 	GRPCPort int
 )
 
+// chainedEncoders are encoder chains which are tested in addition to
+// the single encoders.
+var chainedEncoders = []string{
+	"Base64" + encoder.ChainSeparator + "URL",
+}
+
 type TestCasesMap struct {
 	sync.Mutex
 	m map[string]string
@@ -107,12 +113,14 @@ func GetConfig() *config.Config {
 		RenewSession:       false,
 		BlockStatusCodes:   []int{403},
 		PassStatusCodes:    []int{200, 404},
+		GRPCBlockCodes:     []string{"PERMISSION_DENIED"},
+		GRPCPassCodes:      []string{"OK", "NOT_FOUND"},
 		BlockRegex:         "",
 		PassRegex:          "",
 		NonBlockedAsPassed: false,
 		Workers:            runtime.NumCPU(),
-		RandomDelay:        400,
-		SendDelay:          200,
+		RandomDelay:        0,
+		SendDelay:          0,
 		ReportPath:         path.Join(os.TempDir(), "reports"),
 		TestCase:           "",
 		TestCasesPath:      "",
@@ -126,20 +134,51 @@ func GetConfig() *config.Config {
 	}
 }
 
-func GenerateTestCases() (testCases []*db.Case, testCasesMap *TestCasesMap) {
+// testPairs returns the tested placeholder and encoder pairs. Testing every
+// pair takes too long, so each placeholder is tested with the Plain encoder
+// and each encoder is tested with one of the placeholders in turn.
+func testPairs() [][2]string {
 	var encoders []string
 	var placeholders []string
-	testCasesMap = new(TestCasesMap)
-	testCasesMap.m = make(map[string]string)
 
-	for encoderName, _ := range encoder.Encoders {
+	for encoderName := range encoder.Encoders {
 		encoders = append(encoders, encoderName)
 	}
 
-	for placeholderName, _ := range placeholder.Placeholders {
+	encoders = append(encoders, chainedEncoders...)
+
+	for placeholderName := range placeholder.Placeholders {
 		placeholders = append(placeholders, placeholderName)
 	}
 
+	sort.Strings(encoders)
+	sort.Strings(placeholders)
+
+	var pairs [][2]string
+	seen := make(map[[2]string]bool)
+
+	add := func(pair [2]string) {
+		if !seen[pair] {
+			seen[pair] = true
+			pairs = append(pairs, pair)
+		}
+	}
+
+	for _, placeholderName := range placeholders {
+		add([2]string{placeholderName, encoder.DefaultPlainEncoder.GetName()})
+	}
+
+	for i, encoderName := range encoders {
+		add([2]string{placeholders[i%len(placeholders)], encoderName})
+	}
+
+	return pairs
+}
+
+func GenerateTestCases() (testCases []*db.Case, testCasesMap *TestCasesMap) {
+	testCasesMap = new(TestCasesMap)
+	testCasesMap.m = make(map[string]string)
+
 	testSets := []string{"test-set1", "test-set2", "test-set3"}
 	payloads := []string{"bypassed", "blocked", "unresolved"}
 
@@ -148,34 +187,34 @@ func GenerateTestCases() (testCases []*db.Case, testCasesMap *TestCasesMap) {
 	hash := sha256.New()
 
 	for _, testSet := range testSets {
-		for _, placeholder := range placeholders {
-			for _, encoder := range encoders {
-				name := fmt.Sprintf("%s-%s", placeholder, encoder)
-				testCases = append(testCases, &db.Case{
-					Payloads:       payloads,
-					Encoders:       []string{encoder},
-					Placeholders:   []string{placeholder},
-					Set:            testSet,
-					Name:           name,
-					IsTruePositive: true,
-				})
-
-				for _, payload := range payloads {
-					hash.Reset()
-
-					hash.Write([]byte(testSet))
-					hash.Write([]byte(name))
-					hash.Write([]byte(placeholder))
-					hash.Write([]byte(encoder))
-					hash.Write([]byte(payload))
-
-					debugHeader = hex.EncodeToString(hash.Sum(nil))
-
-					testCasesMap.m[debugHeader] = fmt.Sprintf(
-						"set=%s,name=%s,placeholder=%s,encoder=%s",
-						testSet, name, placeholder, encoder,
-					)
-				}
+		for _, pair := range testPairs() {
+			placeholder, encoder := pair[0], pair[1]
+
+			name := fmt.Sprintf("%s-%s", placeholder, encoder)
+			testCases = append(testCases, &db.Case{
+				Payloads:       payloads,
+				Encoders:       []string{encoder},
+				Placeholders:   []string{placeholder},
+				Set:            testSet,
+				Name:           name,
+				IsTruePositive: true,
+			})
+
+			for _, payload := range payloads {
+				hash.Reset()
+
+				hash.Write([]byte(testSet))
+				hash.Write([]byte(name))
+				hash.Write([]byte(placeholder))
+				hash.Write([]byte(encoder))
+				hash.Write([]byte(payload))
+
+				debugHeader = hex.EncodeToString(hash.Sum(nil))
+
+				testCasesMap.m[debugHeader] = fmt.Sprintf(
+					"set=%s,name=%s,placeholder=%s,encoder=%s",
+					testSet, name, placeholder, encoder,
+				)
 			}
 		}
 	}
@@ -2,6 +2,8 @@ package integration
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"os"
 	"testing"
 	"time"
@@ -32,6 +34,12 @@ func TestGoTestWAF(t *testing.T) {
 
 	w.Run()
 
+	for _, port := range []int{test_config.HTTPPort, test_config.GRPCPort} {
+		if err = waitForListener(fmt.Sprintf("localhost:%d", port), 5*time.Second); err != nil {
+			t.Fatalf("WAF isn't started: %v", err)
+		}
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	t.Cleanup(func() {
@@ -65,6 +73,24 @@ func TestGoTestWAF(t *testing.T) {
 	}
 }
 
+// waitForListener waits until the address accepts connections.
+func waitForListener(addr string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			return conn.Close()
+		}
+
+		if time.Now().After(deadline) {
+			return err
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
 func runGoTestWAF(ctx context.Context, testCases []*db.Case) error {
 	logger := logrus.New()
 	logger.SetLevel(logrus.InfoLevel)
@@ -5,14 +5,80 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"html"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"golang.org/x/text/encoding/unicode"
 	"golang.org/x/text/transform"
+
+	"github.com/wallarm/gotestwaf/internal/payload/encoder"
 )
 
+var sqlVersionedCommentRegexp = regexp.MustCompile(`/\*!(\w+)\*/`)
+
+// decodePayload decodes the payload encoded by the encoder chain. Encoders
+// of the chain are applied from left to right, so they are decoded from
+// right to left.
+func decodePayload(encoderChain, payload string) (string, error) {
+	names := strings.Split(encoderChain, encoder.ChainSeparator)
+
+	var err error
+	for i := len(names) - 1; i >= 0; i-- {
+		payload, err = decodeSingle(names[i], payload)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return payload, nil
+}
+
+func decodeSingle(encoderName, payload string) (string, error) {
+	switch encoderName {
+	case "Base64":
+		return decodeBase64(payload)
+	case "Base64Flat":
+		return decodeBase64(payload)
+	case "JSUnicode":
+		return decodeJSUnicode(payload)
+	case "URL":
+		return decodeURL(payload)
+	case "DoubleURL":
+		return decodeURLRounds(payload, 2)
+	case "TripleURL":
+		return decodeURLRounds(payload, 3)
+	case "Plain":
+		return decodePlain(payload)
+	case "XMLEntity":
+		return decodeXMLEntity(payload)
+	case "IISUnicode":
+		return decodeIISUnicode(payload)
+	case "OverlongUTF8":
+		return decodeOverlongUTF8(payload)
+	case "HTMLDecimal", "HTMLHex":
+		return decodeHTMLEntity(payload)
+	case "FullWidth":
+		return decodeFullWidth(payload)
+	case "SQLComment":
+		return decodeSQLComment(payload)
+	case "RandomCase":
+		return decodeRandomCase(payload)
+	case "RandomWhitespace":
+		return decodeRandomWhitespace(payload)
+	case "NullByte":
+		return decodeNullByte(payload)
+	}
+
+	return "", fmt.Errorf("unknown encoder: %s", encoderName)
+}
+
 func decodeBase64(payload string) (string, error) {
 	switch len(payload) % 4 {
 	case 2:
@@ -54,6 +120,17 @@ func decodeURL(payload string) (string, error) {
 	return value, nil
 }
 
+func decodeURLRounds(payload string, rounds int) (string, error) {
+	var err error
+	for i := 0; i < rounds; i++ {
+		payload, err = decodeURL(payload)
+		if err != nil {
+			return "", err
+		}
+	}
+	return payload, nil
+}
+
 func decodePlain(payload string) (string, error) {
 	return payload, nil
 }
@@ -67,6 +144,104 @@ func decodeXMLEntity(payload string) (string, error) {
 	return res, nil
 }
 
+func decodeIISUnicode(payload string) (string, error) {
+	var units []uint16
+	var b strings.Builder
+
+	for i := 0; i < len(payload); {
+		if strings.HasPrefix(payload[i:], "%u") && i+6 <= len(payload) {
+			c, err := strconv.ParseUint(payload[i+2:i+6], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("couldn't decode IIS unicode encoding: %v", err)
+			}
+			units = append(units, uint16(c))
+			i += 6
+			continue
+		}
+
+		b.WriteString(string(utf16.Decode(units)))
+		units = units[:0]
+
+		b.WriteByte(payload[i])
+		i++
+	}
+
+	b.WriteString(string(utf16.Decode(units)))
+
+	return b.String(), nil
+}
+
+func decodeOverlongUTF8(payload string) (string, error) {
+	value, err := url.PathUnescape(payload)
+	if err != nil {
+		return "", fmt.Errorf("couldn't decode overlong UTF-8 encoding: %v", err)
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(value); {
+		if (value[i] == 0xC0 || value[i] == 0xC1) && i+1 < len(value) && value[i+1]&0xC0 == 0x80 {
+			b.WriteByte((value[i]&0x1F)<<6 | value[i+1]&0x3F)
+			i += 2
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(value[i:])
+		b.WriteRune(r)
+		i += size
+	}
+
+	return b.String(), nil
+}
+
+func decodeHTMLEntity(payload string) (string, error) {
+	return html.UnescapeString(payload), nil
+}
+
+func decodeFullWidth(payload string) (string, error) {
+	return strings.Map(func(r rune) rune {
+		if 0xFF01 <= r && r <= 0xFF5E {
+			return r - 0xFF01 + '!'
+		}
+		return r
+	}, payload), nil
+}
+
+// decodeSQLComment unwraps the keywords from the versioned comments and
+// replaces the inline comments with spaces.
+func decodeSQLComment(payload string) (string, error) {
+	payload = sqlVersionedCommentRegexp.ReplaceAllString(payload, "$1")
+	return strings.ReplaceAll(payload, "/**/", " "), nil
+}
+
+// decodeRandomCase can't restore the original case of the keywords, so it
+// expects them to be in lower case. Other words are not changed by the encoder.
+func decodeRandomCase(payload string) (string, error) {
+	return encoder.MapKeywords(payload, strings.ToLower), nil
+}
+
+func decodeRandomWhitespace(payload string) (string, error) {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '\t', '\n', '\v', '\f', '\r':
+			return ' '
+		}
+		return r
+	}, payload), nil
+}
+
+// decodeNullByte removes the null bytes from the payload. It accepts both
+// percent-encoded and raw null bytes, because the payload is URL-decoded by
+// some placeholders.
+func decodeNullByte(payload string) (string, error) {
+	for _, nullByte := range []string{"%00", "\x00"} {
+		if strings.HasPrefix(payload, nullByte) {
+			return strings.ReplaceAll(payload, nullByte, ""), nil
+		}
+	}
+	return "", errors.New("couldn't decode null byte encoding: null byte not found")
+}
+
 func decodeGRPC(payload string) (string, error) {
 	return payload, nil
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
 
@@ -26,6 +27,61 @@ type grpcServer struct {
 }
 
 func (s *grpcServer) Foo(ctx context.Context, in *gtw_grpc.Request) (*gtw_grpc.Response, error) {
+	return s.handle(ctx, in.GetValue())
+}
+
+func (s *grpcServer) FooClientStream(stream gtw_grpc.ServiceFooBar_FooClientStreamServer) error {
+	value, err := recvStreamValue(stream)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.handle(stream.Context(), value)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+func (s *grpcServer) FooBidiStream(stream gtw_grpc.ServiceFooBar_FooBidiStreamServer) error {
+	value, err := recvStreamValue(stream)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.handle(stream.Context(), value)
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(resp)
+}
+
+// requestStream is implemented by both client-streaming and bidirectional
+// streaming servers.
+type requestStream interface {
+	Recv() (*gtw_grpc.Request, error)
+}
+
+// recvStreamValue joins values of all messages received from the stream.
+func recvStreamValue(stream requestStream) (string, error) {
+	var value strings.Builder
+
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return value.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		value.WriteString(in.GetValue())
+	}
+}
+
+func (s *grpcServer) handle(ctx context.Context, messageValue string) (*gtw_grpc.Response, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		s.errChan <- errors.New("metadata not found")
@@ -81,25 +137,21 @@ func (s *grpcServer) Foo(ctx context.Context, in *gtw_grpc.Request) (*gtw_grpc.R
 		s.errChan <- errors.New("couldn't get `encoder` parameter of test case")
 	}
 
-	placeholderValue = in.GetValue()
-
-	switch encoder {
-	case "Base64":
-		value, err = decodeBase64(placeholderValue)
-	case "Base64Flat":
-		value, err = decodeBase64(placeholderValue)
-	case "JSUnicode":
-		value, err = decodeJSUnicode(placeholderValue)
-	case "URL":
-		value, err = decodeURL(placeholderValue)
-	case "Plain":
-		value, err = decodePlain(placeholderValue)
-	case "XMLEntity":
-		value, err = decodeXMLEntity(placeholderValue)
+	switch placeholder {
+	case "gRPCMetadata":
+		placeholderValue, err = getPayloadFromGRPCMetadata(md, false)
+	case "gRPCBinaryMetadata":
+		placeholderValue, err = getPayloadFromGRPCMetadata(md, true)
 	default:
-		s.errChan <- fmt.Errorf("unknown encoder: %s", encoder)
+		placeholderValue = messageValue
 	}
 
+	if err != nil {
+		s.errChan <- fmt.Errorf("couldn't get encoded payload value: %v", err)
+	}
+
+	value, err = decodePayload(encoder, placeholderValue)
+
 	if err != nil {
 		s.errChan <- fmt.Errorf("couldn't decode payload: %v", err)
 	}
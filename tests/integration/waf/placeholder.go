@@ -1,13 +1,25 @@
 package waf
 
 import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v2"
 
 	ph "github.com/wallarm/gotestwaf/internal/payload/placeholder"
+	pb "github.com/wallarm/gotestwaf/internal/payload/placeholder/grpc"
 )
 
 var (
@@ -15,6 +27,21 @@ var (
 	soapBodyRegexp = regexp.MustCompile(fmt.Sprintf("<ab[a-fA-F0-9]{%d}>.*</ab[a-fA-F0-9]{%[1]d}>", ph.Seed*2))
 	jsonBodyRegexp = regexp.MustCompile(fmt.Sprintf("\"[a-fA-F0-9]{%d}\": \".*\"", ph.Seed*2))
 	urlParamRegexp = regexp.MustCompile(fmt.Sprintf("[a-fA-F0-9]{%d}", ph.Seed*2))
+
+	cookieRegexp          = regexp.MustCompile(fmt.Sprintf("^[a-f0-9]{%d}=", ph.Seed*2))
+	cookiePollutionRegexp = regexp.MustCompile(fmt.Sprintf("^([a-f0-9]{%d})=[a-f0-9]{%[1]d}; ([a-f0-9]{%[1]d})=", ph.Seed*2))
+
+	methodOverrideParamRegexp = regexp.MustCompile(fmt.Sprintf(`^_method=PUT&[a-f0-9]{%d}=`, ph.Seed*2))
+	methodOverrideBodyRegexp  = regexp.MustCompile(fmt.Sprintf(`^[a-f0-9]{%d}=`, ph.Seed*2))
+
+	pollutedParamRegexp = regexp.MustCompile(fmt.Sprintf(`(?:^|[&;])[a-f0-9]{%d}(?:\[\d*\])?=`, ph.Seed*2))
+
+	multipartFilenameRegexp     = regexp.MustCompile(`; filename="(.*)"$`)
+	multipartFilenameStarRegexp = regexp.MustCompile(`; filename\*=UTF-8''(.*)$`)
+	multipartFieldNameRegexp    = regexp.MustCompile(`^form-data; name="(.*)"; filename="[^"]*"$`)
+
+	grpcMetadataRegexp    = regexp.MustCompile(fmt.Sprintf("^x-[a-f0-9]{%d}$", ph.Seed*2))
+	grpcBinMetadataRegexp = regexp.MustCompile(fmt.Sprintf("^x-[a-f0-9]{%d}-bin$", ph.Seed*2))
 )
 
 func getPayloadFromHeader(r *http.Request) (string, error) {
@@ -27,6 +54,36 @@ func getPayloadFromHeader(r *http.Request) (string, error) {
 	return "", errors.New("couldn't get payload from header: required header not found")
 }
 
+func getPayloadFromCookie(r *http.Request) (string, error) {
+	cookie := r.Header.Get("Cookie")
+
+	loc := cookieRegexp.FindStringIndex(cookie)
+	if loc == nil {
+		return "", errors.New("couldn't get payload from cookie: required cookie not found")
+	}
+
+	return cookie[loc[1]:], nil
+}
+
+func getPayloadFromCookieHeader(r *http.Request) (string, error) {
+	if len(r.Header.Values("Cookie")) != 1 {
+		return "", errors.New("couldn't get payload from Cookie header: header not found")
+	}
+
+	return r.Header.Get("Cookie"), nil
+}
+
+func getPayloadFromCookiePollution(r *http.Request) (string, error) {
+	cookie := r.Header.Get("Cookie")
+
+	m := cookiePollutionRegexp.FindStringSubmatchIndex(cookie)
+	if m == nil || cookie[m[2]:m[3]] != cookie[m[4]:m[5]] {
+		return "", errors.New("couldn't get payload from cookie: repeated cookie not found")
+	}
+
+	return cookie[m[1]:], nil
+}
+
 func getPayloadFromHTMLForm(r *http.Request) (string, error) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -58,6 +115,120 @@ func getPayloadFromHTMLMultipartForm(r *http.Request) (string, error) {
 	return "", errors.New("couldn't get payload from multipart form body")
 }
 
+// multipartPart is the only part of the multipart form. The headers of the
+// part are kept as they are sent, without unquoting.
+type multipartPart struct {
+	disposition string
+	contentType string
+	content     string
+}
+
+// readMultipartPart parses the multipart form with the only part. The form
+// is parsed manually, because the mime/multipart package unquotes and
+// validates the parameters of the part headers.
+func readMultipartPart(r *http.Request) (*multipartPart, error) {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse Content-Type: %v", err)
+	}
+
+	boundary := params["boundary"]
+	if boundary == "" {
+		return nil, errors.New("couldn't get multipart boundary")
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read request body: %v", err)
+	}
+
+	form := string(body)
+	start := "--" + boundary + "\r\n"
+	end := "\r\n--" + boundary + "--\r\n"
+
+	if !strings.HasPrefix(form, start) || !strings.HasSuffix(form, end) {
+		return nil, errors.New("couldn't get multipart part: boundary mismatched")
+	}
+
+	form = strings.TrimSuffix(strings.TrimPrefix(form, start), end)
+
+	i := strings.Index(form, "\r\n\r\n")
+	if i == -1 {
+		return nil, errors.New("couldn't get multipart part: headers not found")
+	}
+
+	part := &multipartPart{content: form[i+4:]}
+
+	for _, line := range strings.Split(form[:i], "\r\n") {
+		switch {
+		case strings.HasPrefix(line, "Content-Disposition: "):
+			part.disposition = strings.TrimPrefix(line, "Content-Disposition: ")
+		case strings.HasPrefix(line, "Content-Type: "):
+			part.contentType = strings.TrimPrefix(line, "Content-Type: ")
+		default:
+			return nil, fmt.Errorf("couldn't parse multipart part header: %q", line)
+		}
+	}
+
+	return part, nil
+}
+
+// getMultipartDispositionParam returns the value of the Content-Disposition
+// parameter matched by the regexp.
+func getMultipartDispositionParam(r *http.Request, re *regexp.Regexp) (string, error) {
+	part, err := readMultipartPart(r)
+	if err != nil {
+		return "", err
+	}
+
+	m := re.FindStringSubmatch(part.disposition)
+	if m == nil {
+		return "", errors.New("couldn't get payload from Content-Disposition: parameter not found")
+	}
+
+	return m[1], nil
+}
+
+func getPayloadFromMultipartFilename(r *http.Request) (string, error) {
+	return getMultipartDispositionParam(r, multipartFilenameRegexp)
+}
+
+func getPayloadFromMultipartFilenameStar(r *http.Request) (string, error) {
+	value, err := getMultipartDispositionParam(r, multipartFilenameStarRegexp)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := url.PathUnescape(value)
+	if err != nil {
+		return "", fmt.Errorf("couldn't decode filename*: %v", err)
+	}
+
+	return payload, nil
+}
+
+func getPayloadFromMultipartFieldName(r *http.Request) (string, error) {
+	return getMultipartDispositionParam(r, multipartFieldNameRegexp)
+}
+
+func getPayloadFromMultipartContentType(r *http.Request) (string, error) {
+	part, err := readMultipartPart(r)
+	if err != nil {
+		return "", err
+	}
+
+	return part.contentType, nil
+}
+
+func getPayloadFromMultipartContent(r *http.Request) (string, error) {
+	part, err := readMultipartPart(r)
+	if err != nil {
+		return "", err
+	}
+
+	return part.content, nil
+}
+
 func getPayloadFromJSONBody(r *http.Request) (string, error) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -80,6 +251,146 @@ func getPayloadFromJSONRequest(r *http.Request) (string, error) {
 	return decodeJSUnicode(match[0][15 : len(match[0])-1])
 }
 
+// readJSONObject reads the JSON object with the only field from the request
+// body and returns the key and the value of the field.
+func readJSONObject(r *http.Request) (string, interface{}, error) {
+	var obj map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+		return "", nil, fmt.Errorf("couldn't parse JSON body: %v", err)
+	}
+
+	return jsonField(obj)
+}
+
+// jsonField returns the key and the value of the only field of the JSON
+// object.
+func jsonField(v interface{}) (string, interface{}, error) {
+	obj, ok := v.(map[string]interface{})
+	if !ok || len(obj) != 1 {
+		return "", nil, errors.New("couldn't get payload from JSON body: object with one field not found")
+	}
+
+	for key, value := range obj {
+		return key, value, nil
+	}
+
+	return "", nil, nil
+}
+
+func getPayloadFromJSONNested(r *http.Request) (string, error) {
+	_, value, err := readJSONObject(r)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if payload, ok := value.(string); ok {
+			return payload, nil
+		}
+
+		if _, value, err = jsonField(value); err != nil {
+			return "", err
+		}
+	}
+}
+
+func getPayloadFromJSONArray(r *http.Request) (string, error) {
+	_, value, err := readJSONObject(r)
+	if err != nil {
+		return "", err
+	}
+
+	array, ok := value.([]interface{})
+	if !ok || len(array) != 3 {
+		return "", errors.New("couldn't get payload from JSON body: array not found")
+	}
+
+	payload, ok := array[2].(string)
+	if !ok {
+		return "", errors.New("couldn't get payload from JSON body: array element isn't a string")
+	}
+
+	return payload, nil
+}
+
+func getPayloadFromJSONKey(r *http.Request) (string, error) {
+	key, _, err := readJSONObject(r)
+	return key, err
+}
+
+func getPayloadFromJSONNumericString(r *http.Request) (string, error) {
+	_, value, err := readJSONObject(r)
+	if err != nil {
+		return "", err
+	}
+
+	payload, ok := value.(string)
+	if !ok || payload == "" || payload[0] < '0' || payload[0] > '9' {
+		return "", errors.New("couldn't get payload from JSON body: numeric string not found")
+	}
+
+	return strings.TrimLeft(payload, "0123456789"), nil
+}
+
+// getPayloadFromJSONDuplicateKey returns the value of the last field with the
+// duplicated key, as the payload is placed there by default.
+func getPayloadFromJSONDuplicateKey(r *http.Request) (string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read request body: %v", err)
+	}
+
+	// the value of the last field is decoded
+	var obj map[string]interface{}
+	if err = json.Unmarshal(body, &obj); err != nil {
+		return "", fmt.Errorf("couldn't parse JSON body: %v", err)
+	}
+
+	key, value, err := jsonField(obj)
+	if err != nil {
+		return "", err
+	}
+
+	if n := strings.Count(string(body), `"`+key+`":`); n != 2 {
+		return "", fmt.Errorf("couldn't get payload from JSON body: key is found %d times, want 2", n)
+	}
+
+	payload, ok := value.(string)
+	if !ok {
+		return "", errors.New("couldn't get payload from JSON body: value isn't a string")
+	}
+
+	return payload, nil
+}
+
+func getPayloadFromJSONUnicodeKey(r *http.Request) (string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read request body: %v", err)
+	}
+
+	if !strings.HasPrefix(string(body), `{"\u`) {
+		return "", errors.New("couldn't get payload from JSON body: key isn't escaped")
+	}
+
+	var obj map[string]interface{}
+	if err = json.Unmarshal(body, &obj); err != nil {
+		return "", fmt.Errorf("couldn't parse JSON body: %v", err)
+	}
+
+	_, value, err := jsonField(obj)
+	if err != nil {
+		return "", err
+	}
+
+	payload, ok := value.(string)
+	if !ok {
+		return "", errors.New("couldn't get payload from JSON body: value isn't a string")
+	}
+
+	return payload, nil
+}
+
 func getPayloadFromRequestBody(r *http.Request) (string, error) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -112,6 +423,100 @@ func getPayloadFromURLParam(r *http.Request) (string, error) {
 	return "", errors.New("couldn't get payload from URL parameters: required parameter not found")
 }
 
+// getPayloadFromJWT returns the sub claim of the unsigned token, the token
+// is sent in the Authorization header or in the token cookie.
+func getPayloadFromJWT(r *http.Request, cookie bool) (string, error) {
+	var token string
+	if cookie {
+		token = strings.TrimPrefix(r.Header.Get("Cookie"), "token=")
+	} else {
+		token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[2] != "" {
+		return "", errors.New("couldn't get payload from JWT: unsigned token not found")
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("couldn't decode JWT claims: %v", err)
+	}
+
+	var claims map[string]interface{}
+	if err = json.Unmarshal(claimsJSON, &claims); err != nil {
+		return "", fmt.Errorf("couldn't parse JWT claims: %v", err)
+	}
+
+	payload, ok := claims["sub"].(string)
+	if !ok {
+		return "", errors.New("couldn't get payload from JWT: sub claim not found")
+	}
+
+	return payload, nil
+}
+
+func getPayloadFromMethodOverride(r *http.Request, param bool) (string, error) {
+	re := methodOverrideBodyRegexp
+	if param {
+		re = methodOverrideParamRegexp
+	} else if method := r.Header.Get("X-HTTP-Method-Override"); method != "PUT" {
+		return "", fmt.Errorf("couldn't get overriding method from header: got %q", method)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read request body: %v", err)
+	}
+
+	loc := re.FindIndex(body)
+	if loc == nil {
+		return "", errors.New("couldn't get payload from method override form: payload not found")
+	}
+
+	return string(body[loc[1]:]), nil
+}
+
+// pollutedParamValues returns the raw values of the polluted parameters in the
+// query or the form body. The values aren't unescaped, because the payload
+// parts are sent as is.
+func pollutedParamValues(raw string) []string {
+	locs := pollutedParamRegexp.FindAllStringIndex(raw, -1)
+
+	values := make([]string, 0, len(locs))
+	for i, loc := range locs {
+		end := len(raw)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		values = append(values, raw[loc[1]:end])
+	}
+
+	return values
+}
+
+func getPayloadFromParamPollution(r *http.Request, inQuery, inBody bool) (string, error) {
+	var values []string
+
+	if inQuery {
+		values = append(values, pollutedParamValues(r.URL.RawQuery)...)
+	}
+
+	if inBody {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return "", fmt.Errorf("couldn't read request body: %v", err)
+		}
+		values = append(values, pollutedParamValues(string(body))...)
+	}
+
+	if len(values) < 2 {
+		return "", fmt.Errorf("couldn't get payload from polluted parameters: got %d parameters", len(values))
+	}
+
+	return strings.Join(values, ""), nil
+}
+
 func getPayloadFromURLPath(r *http.Request) (string, error) {
 	payload := r.URL.Path[1:]
 	if recoveryMessage := recover(); recoveryMessage != nil {
@@ -128,3 +533,276 @@ func getPayloadFromXMLBody(r *http.Request) (string, error) {
 	}
 	return string(body), nil
 }
+
+// getXMLElementText returns the text of the XML element with the local name.
+// The text of the CDATA sections is joined.
+func getXMLElementText(r *http.Request, element string) (string, error) {
+	dec := xml.NewDecoder(r.Body)
+
+	var text strings.Builder
+	inside := false
+
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			return "", fmt.Errorf("couldn't get payload from XML body: element %s not found", element)
+		}
+		if err != nil {
+			return "", fmt.Errorf("couldn't parse XML body: %v", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			inside = t.Name.Local == element
+		case xml.CharData:
+			if inside {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			if inside {
+				return text.String(), nil
+			}
+		}
+	}
+}
+
+func getPayloadFromXMLAttribute(r *http.Request) (string, error) {
+	dec := xml.NewDecoder(r.Body)
+
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return "", fmt.Errorf("couldn't get payload from XML attribute: %v", err)
+		}
+
+		if t, ok := token.(xml.StartElement); ok && t.Name.Local == "item" {
+			for _, attr := range t.Attr {
+				if attr.Name.Local == "value" {
+					return attr.Value, nil
+				}
+			}
+		}
+	}
+}
+
+func getPayloadFromXMLProcessingInstruction(r *http.Request) (string, error) {
+	dec := xml.NewDecoder(r.Body)
+
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return "", fmt.Errorf("couldn't get payload from XML processing instruction: %v", err)
+		}
+
+		if t, ok := token.(xml.ProcInst); ok && t.Target != "xml" {
+			return string(t.Inst), nil
+		}
+	}
+}
+
+// getPayloadFromXMLDTD returns the internal DTD as it's sent, because the
+// encoding/xml package doesn't parse the DTD.
+func getPayloadFromXMLDTD(r *http.Request) (string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read request body: %v", err)
+	}
+
+	start := strings.Index(string(body), "[\n")
+	end := strings.LastIndex(string(body), "\n]>")
+	if start == -1 || end < start+2 {
+		return "", errors.New("couldn't get payload from XML DTD: DTD not found")
+	}
+
+	return string(body[start+2 : end]), nil
+}
+
+func getPayloadFromGRPCWeb(r *http.Request) (string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read request body: %v", err)
+	}
+
+	if r.Header.Get("Content-Type") == "application/grpc-web-text+proto" {
+		body, err = base64.StdEncoding.DecodeString(string(body))
+		if err != nil {
+			return "", fmt.Errorf("couldn't decode gRPC-Web text body: %v", err)
+		}
+	}
+
+	if len(body) < 5 || int(binary.BigEndian.Uint32(body[1:5])) != len(body)-5 {
+		return "", errors.New("couldn't get payload from gRPC-Web body: invalid frame")
+	}
+
+	req := &pb.Request{}
+	err = proto.Unmarshal(body[5:], req)
+	if err != nil {
+		return "", fmt.Errorf("couldn't get payload from gRPC-Web body: %v", err)
+	}
+
+	return req.GetValue(), nil
+}
+
+func getPayloadFromConnect(r *http.Request) (string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read request body: %v", err)
+	}
+
+	req := &pb.Request{}
+	err = proto.Unmarshal(body, req)
+	if err != nil {
+		return "", fmt.Errorf("couldn't get payload from Connect body: %v", err)
+	}
+
+	return req.GetValue(), nil
+}
+
+// readMsgpackString reads the MessagePack str value and returns it with the
+// rest of the data.
+func readMsgpackString(b []byte) (string, []byte, error) {
+	if len(b) == 0 {
+		return "", nil, errors.New("unexpected end of MessagePack data")
+	}
+
+	var n, size int
+	switch t := b[0]; {
+	case t&0xe0 == 0xa0:
+		n, size = int(t&0x1f), 1
+	case t == 0xd9 && len(b) >= 2:
+		n, size = int(b[1]), 2
+	case t == 0xda && len(b) >= 3:
+		n, size = int(binary.BigEndian.Uint16(b[1:])), 3
+	case t == 0xdb && len(b) >= 5:
+		n, size = int(binary.BigEndian.Uint32(b[1:])), 5
+	default:
+		return "", nil, fmt.Errorf("MessagePack str expected, got type 0x%02x", t)
+	}
+
+	if len(b) < size+n {
+		return "", nil, errors.New("unexpected end of MessagePack data")
+	}
+
+	return string(b[size : size+n]), b[size+n:], nil
+}
+
+// readCBORString reads the CBOR text string and returns it with the rest of
+// the data.
+func readCBORString(b []byte) (string, []byte, error) {
+	if len(b) == 0 {
+		return "", nil, errors.New("unexpected end of CBOR data")
+	}
+
+	if b[0]>>5 != 3 {
+		return "", nil, fmt.Errorf("CBOR text string expected, got type 0x%02x", b[0])
+	}
+
+	var n, size int
+	switch info := b[0] & 0x1f; {
+	case info < 24:
+		n, size = int(info), 1
+	case info == 24 && len(b) >= 2:
+		n, size = int(b[1]), 2
+	case info == 25 && len(b) >= 3:
+		n, size = int(binary.BigEndian.Uint16(b[1:])), 3
+	case info == 26 && len(b) >= 5:
+		n, size = int(binary.BigEndian.Uint32(b[1:])), 5
+	default:
+		return "", nil, fmt.Errorf("unsupported CBOR text string length 0x%02x", info)
+	}
+
+	if len(b) < size+n {
+		return "", nil, errors.New("unexpected end of CBOR data")
+	}
+
+	return string(b[size : size+n]), b[size+n:], nil
+}
+
+// getPayloadFromMapBody returns the value of the only field of the map
+// encoded with MessagePack or CBOR. The mapHeader is the type of the map with
+// one field.
+func getPayloadFromMapBody(r *http.Request, mapHeader byte, readString func([]byte) (string, []byte, error)) (string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read request body: %v", err)
+	}
+
+	if len(body) == 0 || body[0] != mapHeader {
+		return "", errors.New("couldn't get payload from body: map with one field not found")
+	}
+
+	_, rest, err := readString(body[1:])
+	if err != nil {
+		return "", fmt.Errorf("couldn't read map key: %v", err)
+	}
+
+	payload, rest, err := readString(rest)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read map value: %v", err)
+	}
+
+	if len(rest) != 0 {
+		return "", errors.New("couldn't get payload from body: unexpected data after map")
+	}
+
+	return payload, nil
+}
+
+func getPayloadFromMessagePackBody(r *http.Request) (string, error) {
+	return getPayloadFromMapBody(r, 0x81, readMsgpackString)
+}
+
+func getPayloadFromCBORBody(r *http.Request) (string, error) {
+	return getPayloadFromMapBody(r, 0xa1, readCBORString)
+}
+
+func getPayloadFromProtobufBody(r *http.Request) (string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read request body: %v", err)
+	}
+
+	req := &pb.Request{}
+	if err = proto.Unmarshal(body, req); err != nil {
+		return "", fmt.Errorf("couldn't get payload from protobuf body: %v", err)
+	}
+
+	return req.GetValue(), nil
+}
+
+func getPayloadFromYAMLBody(r *http.Request) (string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read request body: %v", err)
+	}
+
+	var fields map[string]string
+	if err = yaml.Unmarshal(body, &fields); err != nil {
+		return "", fmt.Errorf("couldn't parse YAML body: %v", err)
+	}
+
+	if len(fields) != 1 {
+		return "", fmt.Errorf("couldn't get payload from YAML body: got %d fields, want 1", len(fields))
+	}
+
+	for _, payload := range fields {
+		return payload, nil
+	}
+
+	return "", nil
+}
+
+func getPayloadFromGRPCMetadata(md metadata.MD, binary bool) (string, error) {
+	re := grpcMetadataRegexp
+	if binary {
+		re = grpcBinMetadataRegexp
+	}
+
+	for key, values := range md {
+		if matched := re.MatchString(key); matched {
+			return values[0], nil
+		}
+	}
+
+	return "", errors.New("couldn't get payload from gRPC metadata: required key not found")
+}
@@ -0,0 +1,79 @@
+package waf
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/wallarm/gotestwaf/internal/payload/placeholder/grpc"
+)
+
+func writeHTTPResponse(w http.ResponseWriter, code codes.Code) {
+	switch code {
+	case codes.OK:
+		w.WriteHeader(http.StatusOK)
+	case codes.PermissionDenied:
+		w.WriteHeader(http.StatusForbidden)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// writeGRPCWebResponse always responds with 200 OK and passes the result in
+// the trailer frame, as gRPC-Web servers do.
+func writeGRPCWebResponse(w http.ResponseWriter, text bool, code codes.Code) {
+	var body []byte
+
+	if code == codes.OK {
+		msg, _ := proto.Marshal(&pb.Response{Value: "OK"})
+		body = appendGRPCWebFrame(body, 0, msg)
+	}
+
+	trailer := fmt.Sprintf("grpc-status: %d\r\ngrpc-message: %s\r\n", code, code.String())
+	body = appendGRPCWebFrame(body, 0x80, []byte(trailer))
+
+	contentType := "application/grpc-web+proto"
+	if text {
+		body = []byte(base64.StdEncoding.EncodeToString(body))
+		contentType = "application/grpc-web-text+proto"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func appendGRPCWebFrame(body []byte, flag byte, data []byte) []byte {
+	header := make([]byte, 5)
+	header[0] = flag
+	binary.BigEndian.PutUint32(header[1:], uint32(len(data)))
+
+	return append(append(body, header...), data...)
+}
+
+// writeConnectResponse responds with the binary message or with the JSON
+// error. HTTP status codes of errors follow the Connect protocol specification.
+func writeConnectResponse(w http.ResponseWriter, code codes.Code) {
+	if code == codes.OK {
+		msg, _ := proto.Marshal(&pb.Response{Value: "OK"})
+
+		w.Header().Set("Content-Type", "application/proto")
+		w.WriteHeader(http.StatusOK)
+		w.Write(msg)
+
+		return
+	}
+
+	statusCode, connectCode := http.StatusNotFound, "not_found"
+	if code == codes.PermissionDenied {
+		statusCode, connectCode = http.StatusForbidden, "permission_denied"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	fmt.Fprintf(w, `{"code":"%s","message":"test"}`, connectCode)
+}
@@ -14,6 +14,8 @@ import (
 
 	"github.com/gorilla/websocket"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
 
 	pb "github.com/wallarm/gotestwaf/internal/payload/placeholder/grpc"
 	"github.com/wallarm/gotestwaf/internal/scanner"
@@ -55,6 +57,7 @@ func New(errChan chan<- error, casesMap *config.TestCasesMap) *WAF {
 
 	waf.grpcServer = grpc.NewServer()
 	pb.RegisterServiceFooBarServer(waf.grpcServer, grpcServer)
+	reflection.Register(waf.grpcServer)
 
 	return waf
 }
@@ -167,24 +170,82 @@ func (waf *WAF) httpRequestHandler(w http.ResponseWriter, r *http.Request) {
 	switch placeholder {
 	case "Header":
 		placeholderValue, err = getPayloadFromHeader(r)
+	case "Cookie":
+		placeholderValue, err = getPayloadFromCookie(r)
+	case "CookieHeader":
+		placeholderValue, err = getPayloadFromCookieHeader(r)
+	case "CookiePollution":
+		placeholderValue, err = getPayloadFromCookiePollution(r)
+	case "JWT":
+		placeholderValue, err = getPayloadFromJWT(r, false)
+	case "JWTCookie":
+		placeholderValue, err = getPayloadFromJWT(r, true)
 	case "HTMLForm":
 		placeholderValue, err = getPayloadFromHTMLForm(r)
 	case "HTMLMultipartForm":
 		placeholderValue, err = getPayloadFromHTMLMultipartForm(r)
-	case "JSONBody":
+	case "MultipartFilename":
+		placeholderValue, err = getPayloadFromMultipartFilename(r)
+	case "MultipartFilenameStar":
+		placeholderValue, err = getPayloadFromMultipartFilenameStar(r)
+	case "MultipartFieldName":
+		placeholderValue, err = getPayloadFromMultipartFieldName(r)
+	case "MultipartContentType":
+		placeholderValue, err = getPayloadFromMultipartContentType(r)
+	case "MultipartFileContent", "MultipartQuotedBoundary", "MultipartLongBoundary", "MultipartPaddedBoundary":
+		placeholderValue, err = getPayloadFromMultipartContent(r)
+	case "JSONBody", "JSONFormURLEncoded", "JSONTextPlain":
 		placeholderValue, err = getPayloadFromJSONBody(r)
+	case "JSONNested":
+		placeholderValue, err = getPayloadFromJSONNested(r)
+	case "JSONArray":
+		placeholderValue, err = getPayloadFromJSONArray(r)
+	case "JSONKey":
+		placeholderValue, err = getPayloadFromJSONKey(r)
+	case "JSONNumericString":
+		placeholderValue, err = getPayloadFromJSONNumericString(r)
+	case "JSONDuplicateKey":
+		placeholderValue, err = getPayloadFromJSONDuplicateKey(r)
+	case "JSONUnicodeKey":
+		placeholderValue, err = getPayloadFromJSONUnicodeKey(r)
 	case "JSONRequest":
 		placeholderValue, err = getPayloadFromJSONRequest(r)
+	case "MessagePackBody":
+		placeholderValue, err = getPayloadFromMessagePackBody(r)
+	case "CBORBody":
+		placeholderValue, err = getPayloadFromCBORBody(r)
+	case "ProtobufBody":
+		placeholderValue, err = getPayloadFromProtobufBody(r)
+	case "YAMLBody":
+		placeholderValue, err = getPayloadFromYAMLBody(r)
 	case "RequestBody":
 		placeholderValue, err = getPayloadFromRequestBody(r)
 	case "SOAPBody":
 		placeholderValue, err = getPayloadFromSOAPBody(r)
 	case "URLParam":
 		placeholderValue, err = getPayloadFromURLParam(r)
+	case "URLParamSplit", "URLParamArray", "URLParamIndexed", "URLParamSemicolon":
+		placeholderValue, err = getPayloadFromParamPollution(r, true, false)
+	case "HTMLFormSplit", "HTMLFormArray", "HTMLFormIndexed", "HTMLFormSemicolon":
+		placeholderValue, err = getPayloadFromParamPollution(r, false, true)
+	case "QueryBodySplit":
+		placeholderValue, err = getPayloadFromParamPollution(r, true, true)
 	case "URLPath":
 		placeholderValue, err = getPayloadFromURLPath(r)
 	case "XMLBody":
 		placeholderValue, err = getPayloadFromXMLBody(r)
+	case "XMLAttribute":
+		placeholderValue, err = getPayloadFromXMLAttribute(r)
+	case "XMLCDATA":
+		placeholderValue, err = getXMLElementText(r, "item")
+	case "XMLProcessingInstruction":
+		placeholderValue, err = getPayloadFromXMLProcessingInstruction(r)
+	case "XMLRPC":
+		placeholderValue, err = getXMLElementText(r, "string")
+	case "SOAP12Body":
+		placeholderValue, err = getXMLElementText(r, "value")
+	case "XMLDTD":
+		placeholderValue, err = getPayloadFromXMLDTD(r)
 	case "NonCrudUrlParam":
 		placeholderValue, err = getPayloadFromURLParam(r)
 	case "NonCrudUrlPath":
@@ -193,6 +254,14 @@ func (waf *WAF) httpRequestHandler(w http.ResponseWriter, r *http.Request) {
 		placeholderValue, err = getPayloadFromHeader(r)
 	case "NonCRUDRequestBody":
 		placeholderValue, err = getPayloadFromRequestBody(r)
+	case "MethodOverrideHeader":
+		placeholderValue, err = getPayloadFromMethodOverride(r, false)
+	case "MethodOverrideParam":
+		placeholderValue, err = getPayloadFromMethodOverride(r, true)
+	case "gRPCWeb", "gRPCWebText":
+		placeholderValue, err = getPayloadFromGRPCWeb(r)
+	case "Connect":
+		placeholderValue, err = getPayloadFromConnect(r)
 	default:
 		waf.errChan <- fmt.Errorf("unknown placeholder: %s", placeholder)
 	}
@@ -201,33 +270,26 @@ func (waf *WAF) httpRequestHandler(w http.ResponseWriter, r *http.Request) {
 		waf.errChan <- fmt.Errorf("couldn't get encoded payload value: %v", err)
 	}
 
-	switch encoder {
-	case "Base64":
-		value, err = decodeBase64(placeholderValue)
-	case "Base64Flat":
-		value, err = decodeBase64(placeholderValue)
-	case "JSUnicode":
-		value, err = decodeJSUnicode(placeholderValue)
-	case "URL":
-		value, err = decodeURL(placeholderValue)
-	case "Plain":
-		value, err = decodePlain(placeholderValue)
-	case "XMLEntity":
-		value, err = decodeXMLEntity(placeholderValue)
-	default:
-		waf.errChan <- fmt.Errorf("unknown encoder: %s", encoder)
-	}
+	value, err = decodePayload(encoder, placeholderValue)
 
 	if err != nil {
 		waf.errChan <- fmt.Errorf("couldn't decode payload: %v", err)
 	}
 
+	code := codes.NotFound
 	if matched, _ := regexp.MatchString("bypassed", value); matched {
-		w.WriteHeader(http.StatusOK)
+		code = codes.OK
 	} else if matched, _ = regexp.MatchString("blocked", value); matched {
-		w.WriteHeader(http.StatusForbidden)
-	} else {
-		w.WriteHeader(http.StatusNotFound)
+		code = codes.PermissionDenied
+	}
+
+	switch placeholder {
+	case "gRPCWeb", "gRPCWebText":
+		writeGRPCWebResponse(w, placeholder == "gRPCWebText", code)
+	case "Connect":
+		writeConnectResponse(w, code)
+	default:
+		writeHTTPResponse(w, code)
 	}
 
 	hash := sha256.New()